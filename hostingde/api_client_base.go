@@ -7,23 +7,69 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/cenkalti/backoff/v3"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
+// ErrNotFound is returned when a find request does not return any results,
+// e.g. because the zone or record has been deleted outside of Terraform.
+var ErrNotFound = errors.New("not found")
+
 type Client struct {
 	authToken      string
 	ownerAccountId string
+	baseURL        string
 	HTTPClient     *http.Client
+	RetryPolicy    RetryPolicy
+	PageSize       int
+	shared         *clientState
+}
+
+// clientState is shared by a Client and its copies for other owner accounts.
+type clientState struct {
+	limiter      *rateLimiter
+	inFlight     chan struct{}
+	zoneQueuesMu sync.Mutex
+	zoneQueues   map[string]*zoneQueue
+	zoneNamesMu  sync.Mutex
+	zoneNames    map[string]string
 }
 
-func NewClient(authToken string, ownerAccountId string) *Client {
-	c := Client{authToken: authToken, ownerAccountId: ownerAccountId, HTTPClient: &http.Client{}}
+func NewClient(authToken string, ownerAccountId string, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	c := Client{
+		authToken:      authToken,
+		ownerAccountId: ownerAccountId,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		HTTPClient:     &http.Client{},
+		RetryPolicy:    defaultRetryPolicy(),
+		PageSize:       defaultPageSize,
+		shared: &clientState{
+			zoneQueues: map[string]*zoneQueue{},
+			zoneNames:  map[string]string{},
+		},
+	}
 	return &c
 }
 
+// withOwnerAccount returns a copy of the client acting on behalf of the given account. Rate limits,
+// zone queues and caches are shared with the original client. An empty ownerAccountId returns the client itself.
+func (c *Client) withOwnerAccount(ownerAccountId string) *Client {
+	if ownerAccountId == "" || ownerAccountId == c.ownerAccountId {
+		return c
+	}
+	account := *c
+	account.ownerAccountId = ownerAccountId
+	return &account
+}
+
 func (c *Client) post(uri string, request Request, response interface{}) ([]byte, error) {
 	if request.getAuthToken() == "" {
 		request.setAuthToken(c.authToken)
@@ -38,31 +84,124 @@ func (c *Client) post(uri string, request Request, response interface{}) ([]byte
 	}
 
 	log.Print(string(body))
-	req, err := http.NewRequest(http.MethodPost, uri, bytes.NewReader(body))
+
+	var content []byte
+	operation := func() error {
+		req, err := http.NewRequest(http.MethodPost, uri, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+
+		release := c.acquire()
+		defer release()
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if !c.RetryPolicy.isRetryableTransportError(uri, err) {
+				return backoff.Permanent(fmt.Errorf("error querying API: %w", err))
+			}
+			return fmt.Errorf("error querying API: %w", err)
+		}
+
+		defer resp.Body.Close()
+
+		content, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.New(toUnreadableBodyMessage(uri, content))
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			err := fmt.Errorf("the request %s failed with HTTP status %s", uri, resp.Status)
+			if !c.RetryPolicy.isRetryableStatusCode(uri, resp.StatusCode) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+
+		err = json.Unmarshal(content, response)
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("%v: %s", err, toUnreadableBodyMessage(uri, content)))
+		}
+
+		baseResponse := BaseResponse{}
+		if json.Unmarshal(content, &baseResponse) == nil && c.RetryPolicy.isRetryableResponse(baseResponse) {
+			return newResponseError(uri, baseResponse, content)
+		}
+
+		return nil
+	}
+
+	notify := func(err error, next time.Duration) {
+		log.Printf("[WARN] retrying request %s in %s: %v", uri, next, err)
+	}
+
+	err = backoff.RetryNotify(operation, c.RetryPolicy.backOff(), notify)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error querying API: %v", err)
+	return content, nil
+}
+
+func (c *Client) pageSize() int {
+	if c.PageSize <= 0 {
+		return defaultPageSize
 	}
+	return c.PageSize
+}
 
-	defer resp.Body.Close()
+func toUnreadableBodyMessage(uri string, rawBody []byte) string {
+	return fmt.Sprintf("the request %s sent a response with a body which is an invalid format: %s", uri, strings.Replace(string(rawBody), `\n`, "\n", -1))
+}
 
-	content, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, errors.New(toUnreadableBodyMessage(uri, content))
+// ResponseError is returned when the API answers a request with a status other than success or pending.
+// Use errors.As to inspect the individual API errors, e.g. to branch on specific error codes.
+type ResponseError struct {
+	URI      string
+	Status   string
+	Errors   []APIError
+	Warnings []string
+	Metadata Metadata
+	rawBody  []byte
+}
+
+func newResponseError(uri string, resp BaseResponse, rawBody []byte) *ResponseError {
+	return &ResponseError{
+		URI:      uri,
+		Status:   resp.Status,
+		Errors:   resp.Errors,
+		Warnings: resp.Warnings,
+		Metadata: resp.Metadata,
+		rawBody:  rawBody,
 	}
+}
 
-	err = json.Unmarshal(content, response)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %s", err, toUnreadableBodyMessage(uri, content))
+func (e *ResponseError) Error() string {
+	if len(e.Errors) == 0 {
+		return toUnreadableBodyMessage(e.URI, e.rawBody)
 	}
 
-	return content, nil
+	msgs := make([]string, 0, len(e.Errors))
+	for _, apiErr := range e.Errors {
+		msgs = append(msgs, apiErr.String())
+	}
+
+	msg := fmt.Sprintf("the request %s failed with status %q: %s", e.URI, e.Status, strings.Join(msgs, "; "))
+	if len(e.Warnings) > 0 {
+		msg += fmt.Sprintf(" (warnings: %s)", strings.Join(e.Warnings, "; "))
+	}
+	if e.Metadata.ServerTransactionID != "" {
+		msg += fmt.Sprintf(" [serverTransactionId %s]", e.Metadata.ServerTransactionID)
+	}
+	return msg
 }
 
-func toUnreadableBodyMessage(uri string, rawBody []byte) string {
-	return fmt.Sprintf("the request %s sent a response with a body which is an invalid format:", uri, strings.Replace(string(rawBody), `\n`, "\n", -1))
+// HasCode reports whether the API returned an error with the given code.
+func (e *ResponseError) HasCode(code int) bool {
+	for _, apiErr := range e.Errors {
+		if apiErr.Code == code {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,72 @@
+package hostingde
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient returns a client for a stand-in API server handling all requests with handler.
+// The returned func stops the server.
+func newTestClient(handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+	return NewClient("token", "", server.URL), server.Close
+}
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		codes   []int
+		message string
+	}{
+		{
+			name: "errors with warnings and metadata",
+			body: `{"status":"error","errors":[{"code":10109,"contextPath":"zoneConfig.name","text":"Invalid zone name","value":"ex ample.com"}],` +
+				`"warnings":["deprecated"],"metadata":{"serverTransactionId":"srv-1"}}`,
+			codes: []int{10109},
+			message: `the request %s/zoneCreate failed with status "error": error 10109 at zoneConfig.name: Invalid zone name ` +
+				`(value "ex ample.com") (warnings: deprecated) [serverTransactionId srv-1]`,
+		},
+		{
+			name:    "several errors",
+			body:    `{"status":"error","errors":[{"code":1,"text":"first"},{"code":2,"text":"second","details":["a","b"]}]}`,
+			codes:   []int{1, 2},
+			message: `the request %s/zoneCreate failed with status "error": error 1: first; error 2: second [a, b]`,
+		},
+		{
+			name:    "no errors",
+			body:    `{"status":"error"}`,
+			message: `the request %s/zoneCreate sent a response with a body which is an invalid format: {"status":"error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		body := tt.body
+		c, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+
+		_, err := c.createZone(ZoneCreateRequest{BaseRequest: &BaseRequest{}})
+		closeServer()
+
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) {
+			t.Errorf("%s: error %v is not a ResponseError", tt.name, err)
+			continue
+		}
+		if message := fmt.Sprintf(tt.message, c.baseURL); respErr.Error() != message {
+			t.Errorf("%s: Error() = %q, want %q", tt.name, respErr.Error(), message)
+		}
+		for _, code := range tt.codes {
+			if !respErr.HasCode(code) {
+				t.Errorf("%s: HasCode(%d) = false", tt.name, code)
+			}
+		}
+		if respErr.HasCode(99999) {
+			t.Errorf("%s: HasCode(99999) = true", tt.name)
+		}
+	}
+}
@@ -3,13 +3,44 @@ package hostingde
 // Adapted from hostingde provider in https://github.com/go-acme/lego
 
 import (
-	"errors"
 	"fmt"
 )
 
 // https://www.hosting.de/api/?json#list-recordconfigs
 func (d *Client) listRecords(findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
-	uri := defaultBaseURL + "/recordsFind"
+	findResponse, err := d.findRecords(findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("the request %s returned no results: %w", d.baseURL+"/recordsFind", ErrNotFound)
+	}
+
+	return findResponse, nil
+}
+
+// listAllRecords walks all pages of the result and returns the complete list of records.
+func (d *Client) listAllRecords(findRequest RecordsFindRequest) ([]DNSRecord, error) {
+	var records []DNSRecord
+
+	findRequest.Limit = d.pageSize()
+	for page := 1; ; page++ {
+		findRequest.Page = page
+		findResponse, err := d.findRecords(findRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, findResponse.Response.Data...)
+		if page >= findResponse.Response.TotalPages || len(findResponse.Response.Data) == 0 {
+			return records, nil
+		}
+	}
+}
+
+func (d *Client) findRecords(findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
+	uri := d.baseURL + "/recordsFind"
 
 	findResponse := &RecordsFindResponse{}
 
@@ -18,17 +49,31 @@ func (d *Client) listRecords(findRequest RecordsFindRequest) (*RecordsFindRespon
 		return nil, err
 	}
 
-	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: %s", err, toUnreadableBodyMessage(uri, rawResp))
-	}
-
 	if findResponse.Status != "success" {
-		return findResponse, errors.New(toUnreadableBodyMessage(uri, rawResp))
+		return nil, newResponseError(uri, findResponse.BaseResponse, rawResp)
 	}
 
 	return findResponse, nil
 }
 
+// https://www.hosting.de/api/?json#updating-records
+func (d *Client) updateRecords(updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
+	uri := d.baseURL + "/recordsUpdate"
+
+	updateResponse := &RecordsUpdateResponse{}
+
+	rawResp, err := d.post(uri, updateRequest, updateResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
+		return nil, newResponseError(uri, updateResponse.BaseResponse, rawResp)
+	}
+
+	return updateResponse, nil
+}
+
 func (d *Client) getRecord(findRequest RecordsFindRequest) (*DNSRecord, error) {
 	var record *DNSRecord
 
@@ -0,0 +1,63 @@
+package hostingde
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func pageCount(total int, limit int) int {
+	return (total + limit - 1) / limit
+}
+
+func TestListAllRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		totalPages func(total int, limit int) int
+		records    int
+		requests   int
+	}{
+		{"several pages", 7, pageCount, 7, 3},
+		{"last page full", 6, pageCount, 6, 2},
+		{"no records", 0, pageCount, 0, 1},
+		{"total pages missing", 7, func(total int, limit int) int { return 0 }, 3, 1},
+		{"more pages than records", 4, func(total int, limit int) int { return 5 }, 4, 3},
+	}
+
+	for _, tt := range tests {
+		requests := 0
+		c, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			req := RecordsFindRequest{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+
+			var data []DNSRecord
+			for i := (req.Page - 1) * req.Limit; i < req.Page*req.Limit && i < tt.total; i++ {
+				data = append(data, DNSRecord{ID: fmt.Sprintf("record%d", i)})
+			}
+			resp := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			resp.Response.Data = data
+			resp.Response.Page = req.Page
+			resp.Response.Limit = req.Limit
+			resp.Response.TotalPages = tt.totalPages(tt.total, req.Limit)
+			_ = json.NewEncoder(w).Encode(resp)
+		})
+		c.PageSize = 3
+
+		records, err := c.listAllRecords(RecordsFindRequest{BaseRequest: &BaseRequest{}})
+		closeServer()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if len(records) != tt.records {
+			t.Errorf("%s: %d records, want %d", tt.name, len(records), tt.records)
+		}
+		if requests != tt.requests {
+			t.Errorf("%s: %d requests, want %d", tt.name, requests, tt.requests)
+		}
+	}
+}
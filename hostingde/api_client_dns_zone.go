@@ -4,7 +4,6 @@ package hostingde
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/cenkalti/backoff/v3"
 	"time"
@@ -12,7 +11,39 @@ import (
 
 // https://www.hosting.de/api/?json#list-zoneconfigs
 func (d *Client) listZoneConfigs(findRequest ZoneConfigsFindRequest) (*ZoneConfigsFindResponse, error) {
-	uri := defaultBaseURL + "/zoneConfigsFind"
+	findResponse, err := d.findZoneConfigs(findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("the request %s returned no results: %w", d.baseURL+"/zoneConfigsFind", ErrNotFound)
+	}
+
+	return findResponse, nil
+}
+
+// listAllZoneConfigs walks all pages of the result and returns the complete list of zone configs.
+func (d *Client) listAllZoneConfigs(findRequest ZoneConfigsFindRequest) ([]ZoneConfig, error) {
+	var zoneConfigs []ZoneConfig
+
+	findRequest.Limit = d.pageSize()
+	for page := 1; ; page++ {
+		findRequest.Page = page
+		findResponse, err := d.findZoneConfigs(findRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		zoneConfigs = append(zoneConfigs, findResponse.Response.Data...)
+		if page >= findResponse.Response.TotalPages || len(findResponse.Response.Data) == 0 {
+			return zoneConfigs, nil
+		}
+	}
+}
+
+func (d *Client) findZoneConfigs(findRequest ZoneConfigsFindRequest) (*ZoneConfigsFindResponse, error) {
+	uri := d.baseURL + "/zoneConfigsFind"
 
 	findResponse := &ZoneConfigsFindResponse{}
 
@@ -21,12 +52,8 @@ func (d *Client) listZoneConfigs(findRequest ZoneConfigsFindRequest) (*ZoneConfi
 		return nil, err
 	}
 
-	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: %s", err, toUnreadableBodyMessage(uri, rawResp))
-	}
-
 	if findResponse.Status != "success" && findResponse.Status != "pending" {
-		return findResponse, errors.New(toUnreadableBodyMessage(uri, rawResp))
+		return nil, newResponseError(uri, findResponse.BaseResponse, rawResp)
 	}
 
 	return findResponse, nil
@@ -34,7 +61,7 @@ func (d *Client) listZoneConfigs(findRequest ZoneConfigsFindRequest) (*ZoneConfi
 
 // https://www.hosting.de/api/?json#updating-zones
 func (d *Client) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
-	uri := defaultBaseURL + "/zoneUpdate"
+	uri := d.baseURL + "/zoneUpdate"
 
 	updateResponse := &ZoneUpdateResponse{}
 
@@ -44,7 +71,7 @@ func (d *Client) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateRespons
 	}
 
 	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
-		return nil, errors.New(toUnreadableBodyMessage(uri, rawResp))
+		return nil, newResponseError(uri, updateResponse.BaseResponse, rawResp)
 	}
 
 	return updateResponse, nil
@@ -52,7 +79,7 @@ func (d *Client) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateRespons
 
 // https://www.hosting.de/api/?json#creating-new-zones
 func (d *Client) createZone(createRequest ZoneCreateRequest) (*ZoneCreateResponse, error) {
-	uri := defaultBaseURL + "/zoneCreate"
+	uri := d.baseURL + "/zoneCreate"
 
 	createResponse := &ZoneCreateResponse{}
 
@@ -62,7 +89,7 @@ func (d *Client) createZone(createRequest ZoneCreateRequest) (*ZoneCreateRespons
 	}
 
 	if createResponse.Status != "success" && createResponse.Status != "pending" {
-		return nil, errors.New(toUnreadableBodyMessage(uri, rawResp))
+		return nil, newResponseError(uri, createResponse.BaseResponse, rawResp)
 	}
 
 	return createResponse, nil
@@ -70,7 +97,7 @@ func (d *Client) createZone(createRequest ZoneCreateRequest) (*ZoneCreateRespons
 
 // https://www.hosting.de/api/?json#deleting-zones
 func (d *Client) deleteZone(deleteRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
-	uri := defaultBaseURL + "/zoneDelete"
+	uri := d.baseURL + "/zoneDelete"
 
 	deleteResponse := &ZoneDeleteResponse{}
 
@@ -80,12 +107,63 @@ func (d *Client) deleteZone(deleteRequest ZoneDeleteRequest) (*ZoneDeleteRespons
 	}
 
 	if deleteResponse.Status != "success" && deleteResponse.Status != "pending" {
-		return nil, errors.New(toUnreadableBodyMessage(uri, rawResp))
+		return nil, newResponseError(uri, deleteResponse.BaseResponse, rawResp)
 	}
 
 	return deleteResponse, nil
 }
 
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+func (d *Client) getDNSSecOptions(getRequest DNSSecOptionsGetRequest) (*DNSSecOptions, error) {
+	uri := d.baseURL + "/dnsSecOptionsGet"
+
+	getResponse := &DNSSecOptionsGetResponse{}
+
+	rawResp, err := d.post(uri, getRequest, getResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if getResponse.Status != "success" {
+		return nil, newResponseError(uri, getResponse.BaseResponse, rawResp)
+	}
+
+	return &getResponse.Response, nil
+}
+
+// waitForDNSSecKeys polls the DNSSEC options of a zone until its keys have been generated.
+func (d *Client) waitForDNSSecKeys(zoneName string) (*DNSSecOptions, error) {
+	var options *DNSSecOptions
+	ctx, cancel := context.WithCancel(context.Background())
+
+	operation := func() error {
+		o, err := d.getDNSSecOptions(DNSSecOptionsGetRequest{BaseRequest: &BaseRequest{}, ZoneName: zoneName})
+		if err != nil {
+			cancel()
+			return err
+		}
+
+		if len(o.Keys) == 0 {
+			return fmt.Errorf("no DNSSEC keys generated yet for zone %s", zoneName)
+		}
+
+		options = o
+		return nil
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 3 * time.Second
+	bo.MaxInterval = 10 * bo.InitialInterval
+	bo.MaxElapsedTime = 100 * bo.InitialInterval
+
+	err := backoff.Retry(operation, backoff.WithContext(bo, ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
 func (d *Client) getZone(findRequest ZoneConfigsFindRequest) (*ZoneConfig, error) {
 	var zoneConfig *ZoneConfig
 	ctx, cancel := context.WithCancel(context.Background())
@@ -119,3 +197,23 @@ func (d *Client) getZone(findRequest ZoneConfigsFindRequest) (*ZoneConfig, error
 
 	return zoneConfig, nil
 }
+
+// zoneName returns the name of a zone. Names are cached, as a zone cannot be renamed.
+func (d *Client) zoneName(zoneId string) (string, error) {
+	d.shared.zoneNamesMu.Lock()
+	name, ok := d.shared.zoneNames[zoneId]
+	d.shared.zoneNamesMu.Unlock()
+	if ok {
+		return name, nil
+	}
+
+	zoneConfig, err := getZoneConfig(zoneId, d)
+	if err != nil {
+		return "", err
+	}
+
+	d.shared.zoneNamesMu.Lock()
+	defer d.shared.zoneNamesMu.Unlock()
+	d.shared.zoneNames[zoneId] = zoneConfig.Name
+	return zoneConfig.Name, nil
+}
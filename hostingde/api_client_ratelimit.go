@@ -0,0 +1,79 @@
+package hostingde
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket handing out one token per interval and holding up to burst tokens.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := int(requestsPerSecond)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    burst,
+	}
+}
+
+// wait blocks until a token is available. A nil rateLimiter never blocks.
+func (l *rateLimiter) wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	earliest := now.Add(-time.Duration(l.burst-1) * l.interval)
+	if l.next.Before(earliest) {
+		l.next = earliest
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// SetRateLimit limits the number of requests sent per second. Zero disables the limit.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	c.shared.limiter = newRateLimiter(requestsPerSecond)
+}
+
+// SetMaxConcurrentRequests limits the number of requests in flight at the same time. Zero disables the limit.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.shared.inFlight = nil
+		return
+	}
+	c.shared.inFlight = make(chan struct{}, n)
+}
+
+// acquire waits for a free request slot and a rate limit token. The returned func releases the slot.
+func (c *Client) acquire() func() {
+	if c.shared.inFlight != nil {
+		c.shared.inFlight <- struct{}{}
+	}
+	c.shared.limiter.wait()
+
+	return func() {
+		if c.shared.inFlight != nil {
+			<-c.shared.inFlight
+		}
+	}
+}
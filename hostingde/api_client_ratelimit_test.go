@@ -0,0 +1,66 @@
+package hostingde
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	if newRateLimiter(0) != nil {
+		t.Error("newRateLimiter(0) is not nil")
+	}
+	// a nil limiter never blocks
+	var unlimited *rateLimiter
+	unlimited.wait()
+
+	// 50 requests per second allow a burst of 50, the 10 following requests take 20ms each
+	l := newRateLimiter(50)
+	start := time.Now()
+	for i := 0; i < 60; i++ {
+		l.wait()
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("60 requests at 50 per second took %s, want about 200ms", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	c, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"status":"success"}`)
+	})
+	defer closeServer()
+	c.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.findRecords(RecordsFindRequest{BaseRequest: &BaseRequest{}}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("%d requests in flight at the same time, want 2", maxInFlight)
+	}
+}
@@ -0,0 +1,89 @@
+package hostingde
+
+import (
+	"errors"
+	"github.com/cenkalti/backoff/v3"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultRetryableErrorCodes are the API errors returned while an object is blocked by another running job,
+// e.g. a zone which is still pending after a previous update.
+var defaultRetryableErrorCodes = []int{10205, 10303}
+
+// retryInitialInterval is the delay before the first retry, it grows exponentially with every further attempt.
+var retryInitialInterval = 1 * time.Second
+
+// RetryPolicy controls how requests failing with transient errors are retried.
+// HTTP 429 and 503 responses are always considered transient, API errors only if their code is listed
+// in RetryableErrorCodes. Other 5xx responses and transport errors are retried for requests only reading data,
+// as the server may already have applied other requests. These are only retried if the connection could not
+// be established.
+type RetryPolicy struct {
+	MaxAttempts         int
+	MaxElapsedTime      time.Duration
+	RetryableErrorCodes []int
+}
+
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:         5,
+		MaxElapsedTime:      5 * time.Minute,
+		RetryableErrorCodes: append([]int(nil), defaultRetryableErrorCodes...),
+	}
+}
+
+func (p RetryPolicy) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = retryInitialInterval
+	bo.MaxInterval = 30 * time.Second
+	bo.MaxElapsedTime = p.MaxElapsedTime
+
+	if p.MaxAttempts <= 1 {
+		return &backoff.StopBackOff{}
+	}
+	return backoff.WithMaxRetries(bo, uint64(p.MaxAttempts-1))
+}
+
+func (p RetryPolicy) isRetryableStatusCode(uri string, statusCode int) bool {
+	switch {
+	case statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable:
+		return true
+	case statusCode >= http.StatusInternalServerError:
+		return isReadOnlyRequest(uri)
+	}
+	return false
+}
+
+func (p RetryPolicy) isRetryableResponse(resp BaseResponse) bool {
+	if resp.Status != "error" {
+		return false
+	}
+	for _, apiErr := range resp.Errors {
+		for _, code := range p.RetryableErrorCodes {
+			if apiErr.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRetryableTransportError reports whether a request failing without a response can be sent again.
+func (p RetryPolicy) isRetryableTransportError(uri string, err error) bool {
+	return isReadOnlyRequest(uri) || isConnectionError(err)
+}
+
+// isReadOnlyRequest reports whether the API method only reads data.
+func isReadOnlyRequest(uri string) bool {
+	return strings.HasSuffix(uri, "Find") || strings.HasSuffix(uri, "Get")
+}
+
+// isConnectionError reports whether the request failed before a connection was established,
+// so nothing has been sent to the server.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,60 @@
+package hostingde
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	retryInitialInterval = time.Millisecond
+	defer func() { retryInitialInterval = time.Second }()
+
+	tests := []struct {
+		name        string
+		method      string
+		status      int
+		body        string
+		maxAttempts int
+		attempts    int32
+		success     bool
+	}{
+		{"read-only 502 is retried", "/zoneConfigsFind", http.StatusBadGateway, "", 5, 3, true},
+		{"write 502 is not retried", "/zoneUpdate", http.StatusBadGateway, "", 5, 1, false},
+		{"write 504 is not retried", "/zoneCreate", http.StatusGatewayTimeout, "", 5, 1, false},
+		{"write 503 is retried", "/zoneUpdate", http.StatusServiceUnavailable, "", 5, 3, true},
+		{"write 429 is retried", "/recordsUpdate", http.StatusTooManyRequests, "", 5, 3, true},
+		{"retryable error code", "/zoneUpdate", http.StatusOK, `{"status":"error","errors":[{"code":10205}]}`, 5, 3, true},
+		{"other error code", "/zoneUpdate", http.StatusOK, `{"status":"error","errors":[{"code":10109}]}`, 5, 1, false},
+		{"max attempts", "/zoneConfigsFind", http.StatusBadGateway, "", 2, 2, false},
+		{"single attempt", "/zoneConfigsFind", http.StatusBadGateway, "", 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		var attempts int32
+		tt := tt
+		c, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			// the first two attempts fail, the third one succeeds
+			if atomic.AddInt32(&attempts, 1) <= 2 {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+				return
+			}
+			fmt.Fprint(w, `{"status":"success"}`)
+		})
+		c.RetryPolicy.MaxAttempts = tt.maxAttempts
+
+		response := &BaseResponse{}
+		_, err := c.post(c.baseURL+tt.method, ZoneUpdateRequest{BaseRequest: &BaseRequest{}}, response)
+		closeServer()
+
+		if attempts != tt.attempts {
+			t.Errorf("%s: %d attempts, want %d", tt.name, attempts, tt.attempts)
+		}
+		if success := err == nil && response.Status == "success"; success != tt.success {
+			t.Errorf("%s: success = %v, want %v (error %v)", tt.name, success, tt.success, err)
+		}
+	}
+}
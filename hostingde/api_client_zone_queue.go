@@ -0,0 +1,286 @@
+package hostingde
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// zoneUpdateBatchWindow is the time record changes for a zone are collected before they are sent as one zoneUpdate.
+const zoneUpdateBatchWindow = 500 * time.Millisecond
+
+type zoneUpdateResult struct {
+	response *ZoneUpdateResponse
+	created  []DNSRecord
+	err      error
+}
+
+// zoneRecordsChange is the change submitted by a single caller of updateZoneRecords or createZoneRecords.
+// Only changes with needIDs get the created records assigned.
+type zoneRecordsChange struct {
+	recordsToAdd    []DNSRecord
+	recordsToDelete []DNSRecord
+	needIDs         bool
+	result          chan zoneUpdateResult
+}
+
+type zoneUpdateBatch struct {
+	changes []*zoneRecordsChange
+}
+
+// zoneQueue serializes the zone updates of a single zone and coalesces concurrent record changes.
+type zoneQueue struct {
+	updateMu sync.Mutex
+	mu       sync.Mutex
+	pending  *zoneUpdateBatch
+}
+
+// zoneQueue returns the queue of the zone for the owner account of the client. Queues are separated by
+// account, as a batch is sent with the client of the caller which started it.
+func (c *Client) zoneQueue(zoneId string) *zoneQueue {
+	c.shared.zoneQueuesMu.Lock()
+	defer c.shared.zoneQueuesMu.Unlock()
+
+	key := c.ownerAccountId + "/" + zoneId
+	q, ok := c.shared.zoneQueues[key]
+	if !ok {
+		q = &zoneQueue{}
+		c.shared.zoneQueues[key] = q
+	}
+	return q
+}
+
+// updateZoneRecords adds and deletes records of a zone. Changes to the same zone submitted concurrently are
+// combined into a single ZoneUpdateRequest, every caller receives the response of the combined update.
+// If the combined update fails, the changes are sent separately and each caller receives its own result.
+func (c *Client) updateZoneRecords(zoneId string, recordsToAdd []DNSRecord, recordsToDelete []DNSRecord) (*ZoneUpdateResponse, error) {
+	r := c.submitZoneRecordsChange(zoneId, &zoneRecordsChange{
+		recordsToAdd:    recordsToAdd,
+		recordsToDelete: recordsToDelete,
+	})
+	return r.response, r.err
+}
+
+// createZoneRecords adds records to a zone like updateZoneRecords and returns the created records in the
+// order of records, e.g. to learn their IDs.
+func (c *Client) createZoneRecords(zoneId string, records []DNSRecord) ([]DNSRecord, error) {
+	r := c.submitZoneRecordsChange(zoneId, &zoneRecordsChange{
+		recordsToAdd: records,
+		needIDs:      true,
+	})
+	return r.created, r.err
+}
+
+func (c *Client) submitZoneRecordsChange(zoneId string, change *zoneRecordsChange) zoneUpdateResult {
+	q := c.zoneQueue(zoneId)
+	change.result = make(chan zoneUpdateResult, 1)
+
+	q.mu.Lock()
+	if q.pending == nil {
+		q.pending = &zoneUpdateBatch{}
+		go c.flushZoneQueue(zoneId, q)
+	}
+	q.pending.changes = append(q.pending.changes, change)
+	q.mu.Unlock()
+
+	return <-change.result
+}
+
+func (c *Client) flushZoneQueue(zoneId string, q *zoneQueue) {
+	time.Sleep(zoneUpdateBatchWindow)
+
+	q.updateMu.Lock()
+	defer q.updateMu.Unlock()
+
+	q.mu.Lock()
+	batch := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	c.completeZoneUpdateBatch(zoneId, batch)
+}
+
+// completeZoneUpdateBatch sends the batch and hands the result to every caller. If a batch of several changes
+// fails, the changes are sent one by one, so a single bad change, e.g. deleting a record which no longer
+// exists, does not fail the other changes.
+func (c *Client) completeZoneUpdateBatch(zoneId string, batch *zoneUpdateBatch) {
+	response, created, err := c.sendZoneUpdateBatch(zoneId, batch)
+	if err != nil && len(batch.changes) > 1 {
+		log.Printf("[WARN] combined update of %d changes to zone %s failed, sending them separately: %v", len(batch.changes), zoneId, err)
+		for _, change := range batch.changes {
+			c.completeZoneUpdateBatch(zoneId, &zoneUpdateBatch{changes: []*zoneRecordsChange{change}})
+		}
+		return
+	}
+	if err != nil {
+		for _, change := range batch.changes {
+			change.result <- zoneUpdateResult{err: err}
+		}
+		return
+	}
+
+	var requested [][]DNSRecord
+	for _, change := range batch.changes {
+		if change.needIDs {
+			requested = append(requested, change.recordsToAdd)
+		}
+	}
+	matched, matchErrs := newCreatedRecordMatcher(created).match(requested)
+
+	for _, change := range batch.changes {
+		result := zoneUpdateResult{response: response}
+		if change.needIDs {
+			result.created, result.err = matched[0], matchErrs[0]
+			matched, matchErrs = matched[1:], matchErrs[1:]
+		}
+		change.result <- result
+	}
+}
+
+// sendZoneUpdateBatch sends the combined changes and returns the records which have been created by the update,
+// determined by comparing the record IDs of the zone before and after the update if a change needs them.
+func (c *Client) sendZoneUpdateBatch(zoneId string, batch *zoneUpdateBatch) (*ZoneUpdateResponse, []DNSRecord, error) {
+	zoneConfig, err := getZoneConfig(zoneId, c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := ZoneUpdateRequest{
+		BaseRequest: &BaseRequest{},
+		ZoneConfig:  *zoneConfig,
+	}
+	for _, change := range batch.changes {
+		req.RecordsToAdd = append(req.RecordsToAdd, change.recordsToAdd...)
+		req.RecordsToDelete = append(req.RecordsToDelete, change.recordsToDelete...)
+	}
+	if zoneConfig.Type == "SLAVE" && len(req.RecordsToAdd) > 0 {
+		return nil, nil, fmt.Errorf("records cannot be added to SLAVE zone %s", zoneConfig.Name)
+	}
+
+	needIDs := false
+	for _, change := range batch.changes {
+		needIDs = needIDs || (change.needIDs && len(change.recordsToAdd) > 0)
+	}
+
+	existingIds := map[string]bool{}
+	if needIDs {
+		existing, err := c.listAllRecords(RecordsFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter:      FilterOrChain{Filter: Filter{Field: "ZoneConfigId", Value: zoneId}},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range existing {
+			existingIds[r.ID] = true
+		}
+	}
+
+	resp, err := c.updateZone(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !needIDs {
+		return resp, nil, nil
+	}
+
+	var created []DNSRecord
+	for _, r := range resp.Response.Records {
+		if !existingIds[r.ID] {
+			created = append(created, r)
+		}
+	}
+	return resp, created, nil
+}
+
+// modifyRecords changes existing records in place, keeping their IDs. The modification is serialized with
+// the other updates of the zone, but not combined with them.
+func (c *Client) modifyRecords(zoneId string, records []DNSRecord) error {
+	q := c.zoneQueue(zoneId)
+	q.updateMu.Lock()
+	defer q.updateMu.Unlock()
+
+	req := RecordsUpdateRequest{
+		BaseRequest:     &BaseRequest{},
+		ZoneConfigId:    zoneId,
+		RecordsToModify: records,
+	}
+	_, err := c.updateRecords(req)
+	return err
+}
+
+// createdRecordMatcher assigns the records created by a zone update to the records requested by each caller.
+type createdRecordMatcher struct {
+	unassigned []DNSRecord
+}
+
+func newCreatedRecordMatcher(created []DNSRecord) *createdRecordMatcher {
+	return &createdRecordMatcher{unassigned: append([]DNSRecord(nil), created...)}
+}
+
+// match returns the created record for each of the requested records, for every caller in the order of requested.
+// Records are compared after normalization first, as the API may return the content differently from how it
+// was sent. Records left without a match get the single remaining created record with the same name and type,
+// if there are several the result is ambiguous. A caller with an unmatched record gets nil and an error.
+func (m *createdRecordMatcher) match(requested [][]DNSRecord) ([][]DNSRecord, []error) {
+	matched := make([][]DNSRecord, len(requested))
+	found := make([][]bool, len(requested))
+	for i, records := range requested {
+		matched[i] = make([]DNSRecord, len(records))
+		found[i] = make([]bool, len(records))
+		for j, r := range records {
+			key := normalizedRecordKey(r)
+			matched[i][j], found[i][j] = m.take(func(candidate DNSRecord) bool {
+				return normalizedRecordKey(candidate) == key
+			})
+		}
+	}
+
+	errs := make([]error, len(requested))
+	for i, records := range requested {
+		for j, r := range records {
+			if found[i][j] {
+				continue
+			}
+
+			sameNameAndType := func(candidate DNSRecord) bool {
+				return candidate.Type == r.Type && normalizeRecordName(candidate.Name) == normalizeRecordName(r.Name)
+			}
+			switch n := m.count(sameNameAndType); n {
+			case 0:
+				errs[i] = fmt.Errorf("zone was updated, but the response did not contain the created %s record %s %q", r.Type, r.Name, r.Content)
+			case 1:
+				matched[i][j], found[i][j] = m.take(sameNameAndType)
+				continue
+			default:
+				errs[i] = fmt.Errorf("zone was updated, but the created %s record %s %q is ambiguous, %d new records match", r.Type, r.Name, r.Content, n)
+			}
+			matched[i] = nil
+			break
+		}
+	}
+	return matched, errs
+}
+
+func (m *createdRecordMatcher) count(matches func(DNSRecord) bool) int {
+	n := 0
+	for _, candidate := range m.unassigned {
+		if matches(candidate) {
+			n++
+		}
+	}
+	return n
+}
+
+// take removes and returns the first unassigned record for which matches returns true.
+func (m *createdRecordMatcher) take(matches func(DNSRecord) bool) (DNSRecord, bool) {
+	for i, candidate := range m.unassigned {
+		if matches(candidate) {
+			m.unassigned = append(m.unassigned[:i], m.unassigned[i+1:]...)
+			return candidate, true
+		}
+	}
+	return DNSRecord{}, false
+}
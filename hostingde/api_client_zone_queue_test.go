@@ -0,0 +1,289 @@
+package hostingde
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testZoneServer is a stand-in for the API holding the records of a single zone.
+type testZoneServer struct {
+	mu          sync.Mutex
+	records     []DNSRecord
+	nextID      int
+	zoneUpdates []ZoneUpdateRequest
+	updating    bool
+	overlapping bool
+	delay       time.Duration
+}
+
+func (s *testZoneServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/zoneConfigsFind"):
+		fmt.Fprint(w, `{"status":"success","response":{"totalPages":1,"data":[{"id":"zone1","name":"example.com","type":"NATIVE","status":"active"}]}}`)
+	case strings.HasSuffix(r.URL.Path, "/recordsFind"):
+		s.mu.Lock()
+		data, _ := json.Marshal(s.records)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"status":"success","response":{"totalPages":1,"data":%s}}`, data)
+	case strings.HasSuffix(r.URL.Path, "/zoneUpdate"):
+		req := ZoneUpdateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.zoneUpdate(w, req)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *testZoneServer) zoneUpdate(w http.ResponseWriter, req ZoneUpdateRequest) {
+	s.mu.Lock()
+	s.overlapping = s.overlapping || s.updating
+	s.updating = true
+	s.zoneUpdates = append(s.zoneUpdates, req)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	defer func() { s.updating = false; s.mu.Unlock() }()
+
+	records := append([]DNSRecord(nil), s.records...)
+	for _, del := range req.RecordsToDelete {
+		found := false
+		for i, r := range records {
+			if r.ID == del.ID {
+				records = append(records[:i], records[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			fmt.Fprintf(w, `{"status":"error","errors":[{"code":10209,"text":"record %s not found"}]}`, del.ID)
+			return
+		}
+	}
+	for _, add := range req.RecordsToAdd {
+		s.nextID++
+		add.ID = fmt.Sprintf("record%d", s.nextID)
+		records = append(records, add)
+	}
+	s.records = records
+
+	data, _ := json.Marshal(Zone{Records: records, ZoneConfig: req.ZoneConfig})
+	fmt.Fprintf(w, `{"status":"success","response":%s}`, data)
+}
+
+func TestZoneQueueCoalescesConcurrentChanges(t *testing.T) {
+	s := &testZoneServer{records: []DNSRecord{{ID: "old", Name: "old.example.com", Type: "A", Content: "192.0.2.9"}}}
+	c, closeServer := newTestClient(s.ServeHTTP)
+	defer closeServer()
+
+	var wg sync.WaitGroup
+	created := make([][]DNSRecord, 3)
+	for i := range created {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			records, err := c.createZoneRecords("zone1", []DNSRecord{
+				{Name: fmt.Sprintf("host%d.example.com", i), Type: "A", Content: fmt.Sprintf("192.0.2.%d", i), TTL: 60},
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			created[i] = records
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := c.updateZoneRecords("zone1", nil, []DNSRecord{{ID: "old"}}); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+
+	if len(s.zoneUpdates) != 1 {
+		t.Fatalf("%d zone updates, want 1", len(s.zoneUpdates))
+	}
+	if len(s.zoneUpdates[0].RecordsToAdd) != 3 || len(s.zoneUpdates[0].RecordsToDelete) != 1 {
+		t.Errorf("zone update adds %d and deletes %d records, want 3 and 1",
+			len(s.zoneUpdates[0].RecordsToAdd), len(s.zoneUpdates[0].RecordsToDelete))
+	}
+	for i, records := range created {
+		if len(records) != 1 || records[0].Name != fmt.Sprintf("host%d.example.com", i) || records[0].ID == "" {
+			t.Errorf("caller %d received created records %v", i, records)
+		}
+	}
+}
+
+func TestZoneQueueSendsChangesSeparatelyIfBatchFails(t *testing.T) {
+	s := &testZoneServer{}
+	c, closeServer := newTestClient(s.ServeHTTP)
+	defer closeServer()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = c.updateZoneRecords("zone1", []DNSRecord{
+				{Name: fmt.Sprintf("host%d.example.com", i), Type: "A", Content: "192.0.2.1", TTL: 60},
+			}, nil)
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, errs[2] = c.updateZoneRecords("zone1", nil, []DNSRecord{{ID: "deleted-in-ui"}})
+	}()
+	wg.Wait()
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("valid changes failed: %v, %v", errs[0], errs[1])
+	}
+	if errs[2] == nil {
+		t.Error("deleting a missing record did not fail")
+	}
+	if len(s.zoneUpdates) != 4 {
+		t.Errorf("%d zone updates, want the combined update and 3 separate ones", len(s.zoneUpdates))
+	}
+	if len(s.records) != 2 {
+		t.Errorf("zone contains %d records, want 2", len(s.records))
+	}
+	if s.overlapping {
+		t.Error("zone updates were sent concurrently")
+	}
+}
+
+func TestZoneQueueSerializesUpdates(t *testing.T) {
+	// the first update is still running when the second batch is flushed
+	s := &testZoneServer{delay: zoneUpdateBatchWindow + 300*time.Millisecond}
+	c, closeServer := newTestClient(s.ServeHTTP)
+	defer closeServer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			time.Sleep(time.Duration(i) * (zoneUpdateBatchWindow + 100*time.Millisecond))
+			if _, err := c.updateZoneRecords("zone1", []DNSRecord{{Name: "example.com", Type: "A", Content: "192.0.2.1"}}, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(s.zoneUpdates) != 2 {
+		t.Errorf("%d zone updates, want 2", len(s.zoneUpdates))
+	}
+	if s.overlapping {
+		t.Error("zone updates were sent concurrently")
+	}
+}
+
+func TestCreatedRecordMatcher(t *testing.T) {
+	tests := []struct {
+		name      string
+		created   []DNSRecord
+		requested [][]DNSRecord
+		want      [][]DNSRecord
+		wantErr   []bool
+	}{
+		{
+			name: "normalized match",
+			created: []DNSRecord{
+				{ID: "2", Name: "www.example.com.", Type: "CNAME", Content: "example.com.", TTL: 60},
+				{ID: "1", Name: "example.com", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 60},
+			},
+			requested: [][]DNSRecord{
+				{{Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: 60}},
+				{{Name: "www.example.com", Type: "CNAME", Content: "Example.com", TTL: 60}},
+			},
+			want: [][]DNSRecord{
+				{{ID: "1", Name: "example.com", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 60}},
+				{{ID: "2", Name: "www.example.com.", Type: "CNAME", Content: "example.com.", TTL: 60}},
+			},
+			wantErr: []bool{false, false},
+		},
+		{
+			name: "fallback to name and type",
+			created: []DNSRecord{
+				{ID: "1", Name: "example.com", Type: "CAA", Content: `0 issue letsencrypt.org`, TTL: 60},
+			},
+			requested: [][]DNSRecord{
+				{{Name: "example.com", Type: "CAA", Content: `0 issue "letsencrypt.org"`, TTL: 60}},
+			},
+			want: [][]DNSRecord{
+				{{ID: "1", Name: "example.com", Type: "CAA", Content: `0 issue letsencrypt.org`, TTL: 60}},
+			},
+			wantErr: []bool{false},
+		},
+		{
+			name: "exact matches are assigned before the fallback",
+			created: []DNSRecord{
+				{ID: "1", Name: "example.com", Type: "TXT", Content: "a", TTL: 60},
+				{ID: "2", Name: "example.com", Type: "TXT", Content: "split" + `" "` + "value", TTL: 60},
+			},
+			requested: [][]DNSRecord{
+				{{Name: "example.com", Type: "TXT", Content: "splitvalue", TTL: 60}},
+				{{Name: "example.com", Type: "TXT", Content: "a", TTL: 60}},
+			},
+			want: [][]DNSRecord{
+				{{ID: "2", Name: "example.com", Type: "TXT", Content: "split" + `" "` + "value", TTL: 60}},
+				{{ID: "1", Name: "example.com", Type: "TXT", Content: "a", TTL: 60}},
+			},
+			wantErr: []bool{false, false},
+		},
+		{
+			name: "ambiguous fallback only fails the caller",
+			created: []DNSRecord{
+				{ID: "1", Name: "example.com", Type: "CAA", Content: `0 issue a.example.net`, TTL: 60},
+				{ID: "2", Name: "example.com", Type: "CAA", Content: `0 issue b.example.net`, TTL: 60},
+				{ID: "3", Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 60},
+			},
+			requested: [][]DNSRecord{
+				{
+					{Name: "example.com", Type: "CAA", Content: `0 issue "a.example.net"`, TTL: 60},
+					{Name: "example.com", Type: "CAA", Content: `0 issue "b.example.net"`, TTL: 60},
+				},
+				{{Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 60}},
+			},
+			want: [][]DNSRecord{
+				nil,
+				{{ID: "3", Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 60}},
+			},
+			wantErr: []bool{true, false},
+		},
+		{
+			name:    "missing record",
+			created: nil,
+			requested: [][]DNSRecord{
+				{{Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 60}},
+			},
+			want:    [][]DNSRecord{nil},
+			wantErr: []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		matched, errs := newCreatedRecordMatcher(tt.created).match(tt.requested)
+		if !reflect.DeepEqual(matched, tt.want) {
+			t.Errorf("%s: matched = %v, want %v", tt.name, matched, tt.want)
+		}
+		for i, err := range errs {
+			if (err != nil) != tt.wantErr[i] {
+				t.Errorf("%s: error of caller %d = %v, want error %v", tt.name, i, err, tt.wantErr[i])
+			}
+		}
+	}
+}
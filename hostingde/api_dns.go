@@ -2,10 +2,17 @@ package hostingde
 
 // Adapted from hostingde provider in https://github.com/go-acme/lego
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 const defaultBaseURL = "https://secure.hosting.de/api/dns/v1/json"
 
+// defaultPageSize is the number of entries requested per page when walking all pages of a find request.
+const defaultPageSize = 100
+
 // APIError represents an error in an API response.
 // https://www.hosting.de/api/?json#warnings-and-errors
 type APIError struct {
@@ -17,6 +24,21 @@ type APIError struct {
 	Value         string   `json:"value"`
 }
 
+func (e APIError) String() string {
+	msg := fmt.Sprintf("error %d", e.Code)
+	if e.ContextPath != "" {
+		msg += " at " + e.ContextPath
+	}
+	msg += ": " + e.Text
+	if e.Value != "" {
+		msg += fmt.Sprintf(" (value %q)", e.Value)
+	}
+	if len(e.Details) > 0 {
+		msg += " [" + strings.Join(e.Details, ", ") + "]"
+	}
+	return msg
+}
+
 // Filter is used to filter FindRequests to the API.
 // https://www.hosting.de/api/?json#filter-object
 type Filter struct {
@@ -88,6 +110,18 @@ type DNSRecord struct {
 	LastChangeDate   string `json:"lastChangeDate,omitempty"`
 }
 
+// MarshalJSON sends the priority of MX and SRV records even if it is 0, which is a valid priority.
+func (r DNSRecord) MarshalJSON() ([]byte, error) {
+	type record DNSRecord
+	if !recordTypeRequiresPriority(r.Type) {
+		return json.Marshal(record(r))
+	}
+	return json.Marshal(struct {
+		record
+		Priority int `json:"priority"`
+	}{record(r), r.Priority})
+}
+
 // Zone The Zone Object.
 // https://www.hosting.de/api/?json#the-zone-object
 type Zone struct {
@@ -111,6 +145,23 @@ type ZoneUpdateResponse struct {
 	Response Zone `json:"response"`
 }
 
+// RecordsUpdateRequest represents a API RecordsUpdate request.
+// https://www.hosting.de/api/?json#updating-records
+type RecordsUpdateRequest struct {
+	*BaseRequest
+	ZoneConfigId    string      `json:"zoneConfigId"`
+	RecordsToAdd    []DNSRecord `json:"recordsToAdd"`
+	RecordsToModify []DNSRecord `json:"recordsToModify"`
+	RecordsToDelete []DNSRecord `json:"recordsToDelete"`
+}
+
+// RecordsUpdateResponse represents a response from the API.
+// https://www.hosting.de/api/?json#updating-records
+type RecordsUpdateResponse struct {
+	BaseResponse
+	Response []DNSRecord `json:"response"`
+}
+
 // ZoneCreateRequest represents a API ZoneCreate request.
 // https://www.hosting.de/api/?json#creating-new-zones
 type ZoneCreateRequest struct {
@@ -141,7 +192,6 @@ type ZoneDeleteRequest struct {
 // https://www.hosting.de/api/?json#deleting-zones
 type ZoneDeleteResponse struct {
 	BaseResponse
-	Status string `json:"status"`
 }
 
 // ZoneConfigsFindRequest represents a API ZonesFind request.
@@ -192,6 +242,42 @@ type RecordsFindResponse struct {
 	} `json:"response"`
 }
 
+// DNSSecOptions The DNSSEC options object contains the keys and settings of a signed zone.
+// https://www.hosting.de/api/?json#the-dnssec-options-object
+type DNSSecOptions struct {
+	Keys       []DNSSecKey `json:"keys"`
+	Algorithms []string    `json:"algorithms"`
+	NSECMode   string      `json:"nsecMode"`
+	PublishKSK bool        `json:"publishKsk"`
+}
+
+// DNSSecKey The DNSSEC key object describes a key used to sign a zone.
+// https://www.hosting.de/api/?json#the-dnssec-key-object
+type DNSSecKey struct {
+	KeyData struct {
+		Flags     int    `json:"flags"`
+		Protocol  int    `json:"protocol"`
+		Algorithm int    `json:"algorithm"`
+		PublicKey string `json:"publicKey"`
+	} `json:"keyData"`
+	Comment string `json:"comment"`
+	KeyTag  int    `json:"keyTag"`
+}
+
+// DNSSecOptionsGetRequest represents a API DNSSecOptionsGet request.
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+type DNSSecOptionsGetRequest struct {
+	*BaseRequest
+	ZoneName string `json:"zoneName"`
+}
+
+// DNSSecOptionsGetResponse represents a response from the API.
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+type DNSSecOptionsGetResponse struct {
+	BaseResponse
+	Response DNSSecOptions `json:"response"`
+}
+
 // BaseResponse Common response struct.
 // https://www.hosting.de/api/?json#responses
 type BaseResponse struct {
@@ -212,7 +298,7 @@ func (b *BaseRequest) getAuthToken() string {
 }
 
 func (b *BaseRequest) getOwnerAccountId() string {
-	return b.AuthToken
+	return b.OwnerAccountId
 }
 
 func (b *BaseRequest) setAuthToken(token string) {
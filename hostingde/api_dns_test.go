@@ -0,0 +1,39 @@
+package hostingde
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDNSRecordMarshalJSON(t *testing.T) {
+	tests := []struct {
+		record DNSRecord
+		want   string
+	}{
+		{DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 60}, `{"name":"example.com","type":"MX","content":"mail.example.com","ttl":60,"priority":0}`},
+		{DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10}, `{"name":"example.com","type":"MX","content":"mail.example.com","priority":10}`},
+		{DNSRecord{Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 sip.example.com"}, `{"name":"_sip._tcp.example.com","type":"SRV","content":"5 5060 sip.example.com","priority":0}`},
+		{DNSRecord{Name: "example.com", Type: "A", Content: "192.0.2.1"}, `{"name":"example.com","type":"A","content":"192.0.2.1"}`},
+		{DNSRecord{ID: "record1"}, `{"id":"record1"}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.record)
+		if err != nil {
+			t.Errorf("json.Marshal(%v) returned error: %v", tt.record, err)
+			continue
+		}
+		if string(data) != tt.want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", tt.record, data, tt.want)
+		}
+	}
+
+	// records embedded in requests use MarshalJSON as well
+	data, _ := json.Marshal(ZoneUpdateRequest{RecordsToAdd: []DNSRecord{{Type: "MX", Content: "mail.example.com"}}})
+	var req struct {
+		RecordsToAdd []map[string]interface{} `json:"recordsToAdd"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil || req.RecordsToAdd[0]["priority"] != float64(0) {
+		t.Errorf("priority 0 missing in request %s", data)
+	}
+}
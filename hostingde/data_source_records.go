@@ -0,0 +1,115 @@
+package hostingde
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"strings"
+)
+
+func dataSourceRecords() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_change_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRecordsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	zoneId := d.Get("zone_id").(string)
+	name := d.Get("name").(string)
+	rtype := d.Get("type").(string)
+	content := d.Get("content").(string)
+
+	filters := []Filter{{Field: "ZoneConfigId", Value: zoneId}}
+	if name != "" {
+		filters = append(filters, Filter{Field: "RecordName", Value: name})
+	}
+	if rtype != "" {
+		filters = append(filters, Filter{Field: "RecordType", Value: rtype})
+	}
+	if content != "" {
+		filters = append(filters, Filter{Field: "RecordContent", Value: content})
+	}
+
+	req := RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{
+			SubFilterConnective: "AND",
+			SubFilter:           filters,
+		},
+	}
+	records, err := c.listAllRecords(req)
+	if err != nil {
+		return fmt.Errorf("could not list records of zone %s: %v", zoneId, err)
+	}
+
+	result := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		result = append(result, map[string]interface{}{
+			"id":               r.ID,
+			"name":             r.Name,
+			"type":             r.Type,
+			"content":          r.Content,
+			"ttl":              r.TTL,
+			"priority":         r.Priority,
+			"last_change_date": r.LastChangeDate,
+		})
+	}
+
+	d.SetId(strings.Join([]string{zoneId, name, rtype, content}, "/"))
+	return d.Set("records", result)
+}
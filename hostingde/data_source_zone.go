@@ -0,0 +1,130 @@
+package hostingde
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"strings"
+)
+
+func dataSourceZone() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceZoneRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"master_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"zone_transfer_whitelist": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_server_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dnssec_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"soa_values": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"refresh": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"retry": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"expire": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"negative_ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"last_change_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceZoneRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	filter := Filter{}
+	if id, ok := d.GetOk("id"); ok {
+		filter = Filter{Field: "ZoneConfigId", Value: id.(string)}
+	} else if name, ok := d.GetOk("name"); ok {
+		filter = Filter{Field: "ZoneName", Value: strings.TrimSuffix(name.(string), ".")}
+	} else {
+		return fmt.Errorf("one of id or name must be set")
+	}
+
+	req := ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter:      FilterOrChain{Filter: filter},
+		Limit:       1,
+		Page:        1,
+	}
+	resp, err := c.listZoneConfigs(req)
+	if err != nil {
+		return fmt.Errorf("could not find zone with %s %q: %v", filter.Field, filter.Value, err)
+	}
+	zoneConfig := resp.Response.Data[0]
+
+	d.SetId(zoneConfig.ID)
+	_ = d.Set("name", zoneConfig.Name)
+	_ = d.Set("type", zoneConfig.Type)
+	_ = d.Set("account_id", zoneConfig.AccountID)
+	_ = d.Set("status", zoneConfig.Status)
+	_ = d.Set("master_ip", zoneConfig.MasterIP)
+	_ = d.Set("email_address", zoneConfig.EMailAddress)
+	_ = d.Set("zone_transfer_whitelist", zoneConfig.ZoneTransferWhitelist)
+	_ = d.Set("dns_server_group_id", zoneConfig.DNSServerGroupID)
+	_ = d.Set("dnssec_mode", zoneConfig.DNSSecMode)
+	_ = d.Set("soa_values", flattenSOAValues(zoneConfig.SOAValues))
+	_ = d.Set("last_change_date", zoneConfig.LastChangeDate)
+	return nil
+}
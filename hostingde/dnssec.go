@@ -0,0 +1,90 @@
+package hostingde
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// dnsKeyFlagsKSK are the DNSKEY flags of a key signing key, whose DS record is published in the parent zone.
+const dnsKeyFlagsKSK = 257
+
+// dsDigestTypeSHA256 is the DS digest type for SHA-256, see RFC 4509.
+const dsDigestTypeSHA256 = 2
+
+// dnsKeyRData returns the wire format of the DNSKEY RDATA, see RFC 4034 section 2.1.
+func dnsKeyRData(key DNSSecKey) ([]byte, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key.KeyData.PublicKey), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+
+	rdata := make([]byte, 4, 4+len(publicKey))
+	binary.BigEndian.PutUint16(rdata, uint16(key.KeyData.Flags))
+	rdata[2] = byte(key.KeyData.Protocol)
+	rdata[3] = byte(key.KeyData.Algorithm)
+	return append(rdata, publicKey...), nil
+}
+
+// dnsKeyTag calculates the key tag of a DNSKEY RDATA, see RFC 4034 appendix B.
+func dnsKeyTag(rdata []byte) int {
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			ac += uint32(b)
+		} else {
+			ac += uint32(b) << 8
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return int(ac & 0xFFFF)
+}
+
+// canonicalWireName returns the owner name in canonical wire format, see RFC 4034 section 6.2.
+func canonicalWireName(name string) []byte {
+	var wire []byte
+	for _, label := range strings.Split(strings.TrimSuffix(strings.ToLower(name), "."), ".") {
+		if label == "" {
+			continue
+		}
+		wire = append(wire, byte(len(label)))
+		wire = append(wire, label...)
+	}
+	return append(wire, 0)
+}
+
+// dsDigest calculates the SHA-256 DS digest of a DNSKEY, see RFC 4034 section 5.1.4.
+func dsDigest(zoneName string, rdata []byte) string {
+	digest := sha256.Sum256(append(canonicalWireName(zoneName), rdata...))
+	return strings.ToUpper(hex.EncodeToString(digest[:]))
+}
+
+func flattenDNSSecKeys(zoneName string, keys []DNSSecKey) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		rdata, err := dnsKeyRData(key)
+		if err != nil {
+			return nil, fmt.Errorf("DNSSEC key of zone %s: %v", zoneName, err)
+		}
+
+		keyTag := dnsKeyTag(rdata)
+		digest := dsDigest(zoneName, rdata)
+		result = append(result, map[string]interface{}{
+			"key_tag":     keyTag,
+			"flags":       key.KeyData.Flags,
+			"protocol":    key.KeyData.Protocol,
+			"algorithm":   key.KeyData.Algorithm,
+			"public_key":  key.KeyData.PublicKey,
+			"digest_type": dsDigestTypeSHA256,
+			"digest":      digest,
+			"dnskey_record": fmt.Sprintf("%d %d %d %s",
+				key.KeyData.Flags, key.KeyData.Protocol, key.KeyData.Algorithm, key.KeyData.PublicKey),
+			"ds_record": fmt.Sprintf("%d %d %d %s",
+				keyTag, key.KeyData.Algorithm, dsDigestTypeSHA256, digest),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package hostingde
+
+import (
+	"testing"
+)
+
+func TestDNSKeyTagAndDSDigest(t *testing.T) {
+	// example of RFC 4509 section 2.3
+	key := DNSSecKey{}
+	key.KeyData.Flags = 256
+	key.KeyData.Protocol = 3
+	key.KeyData.Algorithm = 5
+	key.KeyData.PublicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZ " +
+		"DRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9Xzc " +
+		"nOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+	tests := []struct {
+		zoneName string
+		keyTag   int
+		digest   string
+	}{
+		{"dskey.example.com.", 60485, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		{"DSKEY.example.com", 60485, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+	}
+
+	for _, tt := range tests {
+		rdata, err := dnsKeyRData(key)
+		if err != nil {
+			t.Fatalf("dnsKeyRData returned error: %v", err)
+		}
+		if keyTag := dnsKeyTag(rdata); keyTag != tt.keyTag {
+			t.Errorf("dnsKeyTag() = %d, want %d", keyTag, tt.keyTag)
+		}
+		if digest := dsDigest(tt.zoneName, rdata); digest != tt.digest {
+			t.Errorf("dsDigest(%q) = %s, want %s", tt.zoneName, digest, tt.digest)
+		}
+	}
+}
+
+func TestDNSKeyRDataInvalidPublicKey(t *testing.T) {
+	key := DNSSecKey{}
+	key.KeyData.PublicKey = "not base64!"
+	if _, err := dnsKeyRData(key); err == nil {
+		t.Error("dnsKeyRData returned no error for invalid public key")
+	}
+}
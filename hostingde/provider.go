@@ -2,6 +2,8 @@ package hostingde
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"strings"
+	"time"
 )
 
 func Provider() *schema.Provider {
@@ -17,16 +19,102 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("HOSTINGDE_ACCOUNTID", nil),
+				Description: "The account to act on behalf of, e.g. a sub-account of a reseller.",
+			},
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HOSTINGDE_API_URL", defaultBaseURL),
+				Description: "The base URL of the Hosting.de DNS API, e.g. to use a sandbox or mock endpoint.",
+			},
+			"max_attempts": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     defaultRetryPolicy().MaxAttempts,
+				Description: "Maximum number of attempts for requests failing with transient errors, 1 disables retries.",
+			},
+			"max_retry_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     int(defaultRetryPolicy().MaxElapsedTime / time.Second),
+				Description: "Maximum time in seconds spent retrying a single request.",
+			},
+			"retryable_error_codes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "API error codes which are considered transient and retried, e.g. for locked zones. Replaces the default codes.",
+			},
+			"page_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     defaultPageSize,
+				Description: "Number of entries requested per page when listing all zones or records.",
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of API requests per second, 0 means unlimited.",
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of API requests in flight at the same time, 0 means unlimited.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"hostingde_zone":   resourceZone(),
-			"hostingde_record": resourceRecord(),
+			"hostingde_zone":         resourceZone(),
+			"hostingde_record":       resourceRecord(),
+			"hostingde_zone_records": resourceZoneRecords(),
+			"hostingde_record_set":   resourceRecordSet(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"hostingde_zone":    dataSourceZone(),
+			"hostingde_records": dataSourceRecords(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	return NewClient(d.Get("auth_token").(string), d.Get("owner_account_id").(string)), nil
+	c := NewClient(
+		d.Get("auth_token").(string),
+		d.Get("owner_account_id").(string),
+		d.Get("base_url").(string),
+	)
+
+	c.RetryPolicy.MaxAttempts = d.Get("max_attempts").(int)
+	c.RetryPolicy.MaxElapsedTime = time.Duration(d.Get("max_retry_time").(int)) * time.Second
+	if codes, ok := d.GetOk("retryable_error_codes"); ok {
+		c.RetryPolicy.RetryableErrorCodes = nil
+		for _, code := range codes.([]interface{}) {
+			c.RetryPolicy.RetryableErrorCodes = append(c.RetryPolicy.RetryableErrorCodes, code.(int))
+		}
+	}
+
+	c.PageSize = d.Get("page_size").(int)
+	c.SetRateLimit(d.Get("requests_per_second").(float64))
+	c.SetMaxConcurrentRequests(d.Get("max_concurrent_requests").(int))
+
+	return c, nil
+}
+
+// resourceClient returns the client acting on behalf of the owner_account_id of the resource, if set.
+func resourceClient(d interface{ Get(string) interface{} }, m interface{}) *Client {
+	c := m.(*Client)
+	ownerAccountId, _ := d.Get("owner_account_id").(string)
+	return c.withOwnerAccount(ownerAccountId)
+}
+
+// importOwnerAccount handles import IDs of the form <ownerAccountId>:<id>, setting owner_account_id
+// and leaving <id> as the ID to import. Colons after a slash are part of the ID, e.g. in AAAA record content.
+func importOwnerAccount(d *schema.ResourceData) {
+	i := strings.Index(d.Id(), ":")
+	if i < 0 || strings.Contains(d.Id()[:i], "/") {
+		return
+	}
+	_ = d.Set("owner_account_id", d.Id()[:i])
+	d.SetId(d.Id()[i+1:])
 }
@@ -0,0 +1,61 @@
+package hostingde
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestImportOwnerAccount(t *testing.T) {
+	tests := []struct {
+		id             string
+		wantId         string
+		ownerAccountId string
+	}{
+		{"example.com", "example.com", ""},
+		{"sub:example.com", "example.com", "sub"},
+		{"sub:zone1/www/AAAA/2001:db8::1", "zone1/www/AAAA/2001:db8::1", "sub"},
+		{"zone1/www/AAAA/2001:db8::1", "zone1/www/AAAA/2001:db8::1", ""},
+	}
+
+	for _, tt := range tests {
+		d := resourceRecord().Data(nil)
+		d.SetId(tt.id)
+		importOwnerAccount(d)
+		if d.Id() != tt.wantId || d.Get("owner_account_id").(string) != tt.ownerAccountId {
+			t.Errorf("importOwnerAccount(%q) = %q with owner_account_id %q, want %q with %q",
+				tt.id, d.Id(), d.Get("owner_account_id"), tt.wantId, tt.ownerAccountId)
+		}
+	}
+}
+
+func TestZoneImportOwnerAccount(t *testing.T) {
+	var ownerAccountIds []string
+	c, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		req := BaseRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		ownerAccountIds = append(ownerAccountIds, req.OwnerAccountId)
+		fmt.Fprint(w, `{"status":"success","response":{"totalPages":1,"data":[{"id":"zone1","name":"example.com","status":"active"}]}}`)
+	})
+	defer closeServer()
+
+	d := resourceZone().Data(nil)
+	d.SetId("sub:example.com")
+	if _, err := resourceZoneImport(d, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "zone1" || d.Get("owner_account_id").(string) != "sub" {
+		t.Errorf("imported zone %q with owner_account_id %q, want zone1 with sub", d.Id(), d.Get("owner_account_id"))
+	}
+	if len(ownerAccountIds) != 1 || ownerAccountIds[0] != "sub" {
+		t.Errorf("lookup sent with owner accounts %v, want [sub]", ownerAccountIds)
+	}
+}
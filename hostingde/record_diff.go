@@ -0,0 +1,50 @@
+package hostingde
+
+// diffRecords compares records by their normalized values and returns the records of desired missing in actual
+// and the records of actual not in desired.
+func diffRecords(desired []DNSRecord, actual []DNSRecord) (recordsToAdd []DNSRecord, recordsToDelete []DNSRecord) {
+	actualByKey := map[string][]DNSRecord{}
+	for _, r := range actual {
+		actualByKey[normalizedRecordKey(r)] = append(actualByKey[normalizedRecordKey(r)], r)
+	}
+
+	for _, r := range desired {
+		key := normalizedRecordKey(r)
+		if len(actualByKey[key]) > 0 {
+			actualByKey[key] = actualByKey[key][1:]
+			continue
+		}
+		recordsToAdd = append(recordsToAdd, r)
+	}
+
+	for _, r := range actual {
+		key := normalizedRecordKey(r)
+		if len(actualByKey[key]) > 0 && actualByKey[key][0].ID == r.ID {
+			recordsToDelete = append(recordsToDelete, DNSRecord{ID: r.ID})
+			actualByKey[key] = actualByKey[key][1:]
+		}
+	}
+
+	return recordsToAdd, recordsToDelete
+}
+
+// keepConfiguredRecordValues returns the actual records with the name and content of the equivalent configured
+// record, as the API may return them in a different form, e.g. TXT content wrapped in quotes.
+func keepConfiguredRecordValues(configured []DNSRecord, actual []DNSRecord) []DNSRecord {
+	configuredByKey := map[string][]DNSRecord{}
+	for _, r := range configured {
+		configuredByKey[equivalentRecordKey(r)] = append(configuredByKey[equivalentRecordKey(r)], r)
+	}
+
+	records := make([]DNSRecord, 0, len(actual))
+	for _, r := range actual {
+		key := equivalentRecordKey(r)
+		if len(configuredByKey[key]) > 0 {
+			r.Name = configuredByKey[key][0].Name
+			r.Content = configuredByKey[key][0].Content
+			configuredByKey[key] = configuredByKey[key][1:]
+		}
+		records = append(records, r)
+	}
+	return records
+}
@@ -0,0 +1,88 @@
+package hostingde
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  []DNSRecord
+		actual   []DNSRecord
+		toAdd    []DNSRecord
+		toDelete []DNSRecord
+	}{
+		{
+			name:    "equal after normalization",
+			desired: []DNSRecord{{Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: 60}},
+			actual:  []DNSRecord{{ID: "1", Name: "Example.com.", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 60}},
+		},
+		{
+			name:     "changed content",
+			desired:  []DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 60}},
+			actual:   []DNSRecord{{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 60}},
+			toAdd:    []DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 60}},
+			toDelete: []DNSRecord{{ID: "1"}},
+		},
+		{
+			name:     "changed ttl",
+			desired:  []DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}},
+			actual:   []DNSRecord{{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 60}},
+			toAdd:    []DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}},
+			toDelete: []DNSRecord{{ID: "1"}},
+		},
+		{
+			name: "duplicate actual records",
+			desired: []DNSRecord{
+				{Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 60, Priority: 10},
+			},
+			actual: []DNSRecord{
+				{ID: "1", Name: "example.com", Type: "MX", Content: "mail.example.com.", TTL: 60, Priority: 10},
+				{ID: "2", Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 60, Priority: 10},
+			},
+			toDelete: []DNSRecord{{ID: "2"}},
+		},
+		{
+			name:    "only desired",
+			desired: []DNSRecord{{Name: "example.com", Type: "A", Content: "192.0.2.1"}},
+			toAdd:   []DNSRecord{{Name: "example.com", Type: "A", Content: "192.0.2.1"}},
+		},
+		{
+			name:     "only actual",
+			actual:   []DNSRecord{{ID: "1", Name: "example.com", Type: "A", Content: "192.0.2.1"}},
+			toDelete: []DNSRecord{{ID: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		toAdd, toDelete := diffRecords(tt.desired, tt.actual)
+		if !reflect.DeepEqual(toAdd, tt.toAdd) {
+			t.Errorf("%s: recordsToAdd = %v, want %v", tt.name, toAdd, tt.toAdd)
+		}
+		if !reflect.DeepEqual(toDelete, tt.toDelete) {
+			t.Errorf("%s: recordsToDelete = %v, want %v", tt.name, toDelete, tt.toDelete)
+		}
+	}
+}
+
+func TestKeepConfiguredRecordValues(t *testing.T) {
+	configured := []DNSRecord{
+		{Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: 60},
+		{Name: "www.example.com", Type: "CNAME", Content: "Example.com", TTL: 60},
+	}
+	actual := []DNSRecord{
+		{ID: "1", Name: "example.com.", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 300},
+		{ID: "2", Name: "www.example.com", Type: "CNAME", Content: "example.com.", TTL: 60},
+		{ID: "3", Name: "example.com", Type: "TXT", Content: `"other"`, TTL: 60},
+	}
+	want := []DNSRecord{
+		{ID: "1", Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: 300},
+		{ID: "2", Name: "www.example.com", Type: "CNAME", Content: "Example.com", TTL: 60},
+		{ID: "3", Name: "example.com", Type: "TXT", Content: `"other"`, TTL: 60},
+	}
+
+	if got := keepConfiguredRecordValues(configured, actual); !reflect.DeepEqual(got, want) {
+		t.Errorf("keepConfiguredRecordValues() = %v, want %v", got, want)
+	}
+}
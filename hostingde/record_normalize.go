@@ -0,0 +1,115 @@
+package hostingde
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net"
+	"strings"
+)
+
+// normalizeRecordName returns the name in lower case without a trailing dot.
+func normalizeRecordName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// expandRecordName resolves a record name relative to the zone and normalizes it. "@" is the zone apex,
+// names ending with a dot are absolute, all other names not ending with the zone name are relative to it.
+func expandRecordName(name string, zoneName string) string {
+	zone := normalizeRecordName(zoneName)
+	absolute := strings.HasSuffix(strings.TrimSpace(name), ".")
+	name = normalizeRecordName(name)
+
+	if name == "@" {
+		return zone
+	}
+	if absolute || name == "" || zone == "" || name == zone || strings.HasSuffix(name, "."+zone) {
+		return name
+	}
+	return name + "." + zone
+}
+
+// relativeRecordName returns the name relative to the zone, "@" for the zone apex. Names outside of the zone
+// are returned as is.
+func relativeRecordName(name string, zoneName string) string {
+	zone := normalizeRecordName(zoneName)
+	normalized := normalizeRecordName(name)
+	if normalized == zone {
+		return "@"
+	}
+	if zone != "" && strings.HasSuffix(normalized, "."+zone) {
+		return strings.TrimSuffix(normalized, "."+zone)
+	}
+	return name
+}
+
+// normalizeHostname returns the hostname in lower case without a trailing dot.
+func normalizeHostname(hostname string) string {
+	return normalizeRecordName(hostname)
+}
+
+// normalizeRecordContent returns the content in the form the API uses for records of the given type,
+// so configured and returned content can be compared.
+func normalizeRecordContent(rtype string, content string) string {
+	content = strings.TrimSpace(content)
+
+	switch rtype {
+	case "A", "AAAA":
+		if ip := net.ParseIP(content); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "MX", "PTR", "ALIAS":
+		return normalizeHostname(content)
+	case "SRV":
+		fields := strings.Fields(content)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = normalizeHostname(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case "TXT", "SPF":
+		if len(content) >= 2 && strings.HasPrefix(content, `"`) && strings.HasSuffix(content, `"`) {
+			return content[1 : len(content)-1]
+		}
+	}
+	return content
+}
+
+// normalizedRecordKey identifies a record by its normalized values, ignoring its ID.
+func normalizedRecordKey(r DNSRecord) string {
+	return fmt.Sprintf("%s|%s|%s|%d|%d",
+		normalizeRecordName(r.Name), r.Type, normalizeRecordContent(r.Type, r.Content), r.TTL, r.Priority)
+}
+
+// equivalentRecordKey identifies a record by its normalized name, type, content and priority, so records
+// differing only in the form of their values or their TTL have the same key.
+func equivalentRecordKey(r DNSRecord) string {
+	return fmt.Sprintf("%s|%s|%s|%d", normalizeRecordName(r.Name), r.Type, normalizeRecordContent(r.Type, r.Content), r.Priority)
+}
+
+// recordZoneName derives the zone name from a record name and its fully-qualified form.
+// It returns an empty string if the name is not relative to the zone.
+func recordZoneName(name string, fqdn string) string {
+	name = normalizeRecordName(name)
+	fqdn = normalizeRecordName(fqdn)
+	if name == "@" {
+		return fqdn
+	}
+	if strings.HasPrefix(fqdn, name+".") {
+		return strings.TrimPrefix(fqdn, name+".")
+	}
+	return ""
+}
+
+func suppressEquivalentRecordName(k, old, new string, d *schema.ResourceData) bool {
+	if normalizeRecordName(old) == normalizeRecordName(new) {
+		return true
+	}
+
+	fqdn, _ := d.Get("fqdn").(string)
+	zoneName := recordZoneName(old, fqdn)
+	return zoneName != "" && expandRecordName(new, zoneName) == normalizeRecordName(fqdn)
+}
+
+func suppressEquivalentRecordContent(k, old, new string, d *schema.ResourceData) bool {
+	rtype := d.Get("type").(string)
+	return normalizeRecordContent(rtype, old) == normalizeRecordContent(rtype, new)
+}
@@ -0,0 +1,92 @@
+package hostingde
+
+import (
+	"testing"
+)
+
+func TestNormalizeRecordContent(t *testing.T) {
+	tests := []struct {
+		rtype   string
+		content string
+		want    string
+	}{
+		{"A", " 192.0.2.1 ", "192.0.2.1"},
+		{"AAAA", "2001:DB8:0:0:0:0:0:1", "2001:db8::1"},
+		{"CNAME", "WWW.Example.com.", "www.example.com"},
+		{"MX", "mail.example.com.", "mail.example.com"},
+		{"SRV", "5  5060 SIP.example.com.", "5 5060 sip.example.com"},
+		{"TXT", `"v=spf1 -all"`, "v=spf1 -all"},
+		{"TXT", "v=spf1 -all", "v=spf1 -all"},
+		{"TXT", `"`, `"`},
+		{"CAA", `0 issue "letsencrypt.org"`, `0 issue "letsencrypt.org"`},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRecordContent(tt.rtype, tt.content); got != tt.want {
+			t.Errorf("normalizeRecordContent(%q, %q) = %q, want %q", tt.rtype, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestExpandRecordName(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneName string
+		want     string
+	}{
+		{"@", "example.com", "example.com"},
+		{"www", "example.com", "www.example.com"},
+		{"WWW", "Example.com.", "www.example.com"},
+		{"www.example.com", "example.com", "www.example.com"},
+		{"www.example.com.", "example.com", "www.example.com"},
+		{"example.com", "example.com", "example.com"},
+		{"other.org.", "example.com", "other.org"},
+		{"other.org", "example.com", "other.org.example.com"},
+		{"www", "", "www"},
+		{"", "example.com", ""},
+	}
+
+	for _, tt := range tests {
+		if got := expandRecordName(tt.name, tt.zoneName); got != tt.want {
+			t.Errorf("expandRecordName(%q, %q) = %q, want %q", tt.name, tt.zoneName, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeRecordName(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneName string
+		want     string
+	}{
+		{"example.com", "example.com", "@"},
+		{"www.example.com.", "example.com", "www"},
+		{"a.b.Example.com", "example.com", "a.b"},
+		{"other.org", "example.com", "other.org"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeRecordName(tt.name, tt.zoneName); got != tt.want {
+			t.Errorf("relativeRecordName(%q, %q) = %q, want %q", tt.name, tt.zoneName, got, tt.want)
+		}
+	}
+}
+
+func TestRecordZoneName(t *testing.T) {
+	tests := []struct {
+		name string
+		fqdn string
+		want string
+	}{
+		{"@", "example.com", "example.com"},
+		{"www", "www.example.com", "example.com"},
+		{"www.example.com", "www.example.com", ""},
+		{"mail", "www.example.com", ""},
+	}
+
+	for _, tt := range tests {
+		if got := recordZoneName(tt.name, tt.fqdn); got != tt.want {
+			t.Errorf("recordZoneName(%q, %q) = %q, want %q", tt.name, tt.fqdn, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package hostingde
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"strconv"
+	"strings"
+)
+
+// structuredField is a field of the record content, fields are separated by spaces in the content.
+type structuredField struct {
+	name   string
+	typ    schema.ValueType
+	quoted bool
+}
+
+// structuredRecord describes a nested block of hostingde_record which is serialized into the record content.
+type structuredRecord struct {
+	block  string
+	fields []structuredField
+}
+
+// structuredRecords maps record types to the nested block describing their content.
+var structuredRecords = map[string]structuredRecord{
+	"SRV": {block: "srv", fields: []structuredField{
+		{name: "weight", typ: schema.TypeInt},
+		{name: "port", typ: schema.TypeInt},
+		{name: "target", typ: schema.TypeString},
+	}},
+	"CAA": {block: "caa", fields: []structuredField{
+		{name: "flags", typ: schema.TypeInt},
+		{name: "tag", typ: schema.TypeString},
+		{name: "value", typ: schema.TypeString, quoted: true},
+	}},
+	"TLSA": {block: "tlsa", fields: []structuredField{
+		{name: "usage", typ: schema.TypeInt},
+		{name: "selector", typ: schema.TypeInt},
+		{name: "matching_type", typ: schema.TypeInt},
+		{name: "data", typ: schema.TypeString},
+	}},
+	"SSHFP": {block: "sshfp", fields: []structuredField{
+		{name: "algorithm", typ: schema.TypeInt},
+		{name: "fingerprint_type", typ: schema.TypeInt},
+		{name: "fingerprint", typ: schema.TypeString},
+	}},
+}
+
+func structuredRecordSchema(rtype string) *schema.Schema {
+	r := structuredRecords[rtype]
+
+	conflicts := []string{"content"}
+	for _, other := range structuredRecords {
+		if other.block != r.block {
+			conflicts = append(conflicts, other.block)
+		}
+	}
+
+	fields := map[string]*schema.Schema{}
+	for _, f := range r.fields {
+		fields[f.name] = &schema.Schema{
+			Type:     f.typ,
+			Required: true,
+		}
+	}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflicts,
+		Description:   fmt.Sprintf("Structured content of %s records, alternative to content.", rtype),
+		Elem:          &schema.Resource{Schema: fields},
+	}
+}
+
+// structuredRecordContent serializes the nested block of the record type into the record content.
+// It returns false if the block is not set.
+func structuredRecordContent(rtype string, block []interface{}) (string, bool) {
+	r, ok := structuredRecords[rtype]
+	if !ok || len(block) == 0 || block[0] == nil {
+		return "", false
+	}
+
+	values := block[0].(map[string]interface{})
+	parts := make([]string, 0, len(r.fields))
+	for _, f := range r.fields {
+		value := fmt.Sprint(values[f.name])
+		if f.quoted {
+			value = quoteCharacterString(value)
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, " "), true
+}
+
+// structuredRecordKnown reports whether all fields of the nested block of the record type are known,
+// which they are not while they are interpolated from resources not created yet.
+func structuredRecordKnown(rtype string, newValueKnown func(string) bool) bool {
+	r := structuredRecords[rtype]
+	for _, f := range r.fields {
+		if !newValueKnown(r.block + ".0." + f.name) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStructuredRecordContent parses the record content into the nested block of the record type.
+// It returns nil if the type has no nested block or the content cannot be parsed.
+func parseStructuredRecordContent(rtype string, content string) []interface{} {
+	r, ok := structuredRecords[rtype]
+	if !ok {
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(content), " ", len(r.fields))
+	if len(parts) != len(r.fields) {
+		return nil
+	}
+
+	values := map[string]interface{}{}
+	for i, f := range r.fields {
+		value := strings.TrimSpace(parts[i])
+		switch {
+		case f.typ == schema.TypeInt:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil
+			}
+			values[f.name] = n
+		case f.quoted:
+			values[f.name] = unquoteCharacterString(value)
+		default:
+			values[f.name] = value
+		}
+	}
+	return []interface{}{values}
+}
+
+// quoteCharacterString quotes the value as a character string of a zone file, see RFC 1035 section 5.1.
+func quoteCharacterString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// unquoteCharacterString removes the quotes and escapes of a character string. Unquoted values are returned as is.
+func unquoteCharacterString(value string) string {
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return value
+	}
+
+	var b strings.Builder
+	escaped := false
+	for _, r := range value[1 : len(value)-1] {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// structuredRecordBlock returns the nested block set in the configuration, if any, and the record type it belongs to.
+func structuredRecordBlock(get func(string) (interface{}, bool)) (string, []interface{}) {
+	for rtype, r := range structuredRecords {
+		if v, ok := get(r.block); ok && len(v.([]interface{})) > 0 {
+			return rtype, v.([]interface{})
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,66 @@
+package hostingde
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStructuredRecordContent(t *testing.T) {
+	tests := []struct {
+		rtype   string
+		content string
+		want    []interface{}
+	}{
+		{"SRV", "5 5060 sip.example.com", []interface{}{map[string]interface{}{
+			"weight": 5, "port": 5060, "target": "sip.example.com",
+		}}},
+		{"CAA", `0 issue "letsencrypt.org"`, []interface{}{map[string]interface{}{
+			"flags": 0, "tag": "issue", "value": "letsencrypt.org",
+		}}},
+		{"CAA", `0 iodef "mailto:a\"b\\c@example.com"`, []interface{}{map[string]interface{}{
+			"flags": 0, "tag": "iodef", "value": `mailto:a"b\c@example.com`,
+		}}},
+		{"CAA", `0 issue "ca\.example\net"`, []interface{}{map[string]interface{}{
+			"flags": 0, "tag": "issue", "value": "ca.examplenet",
+		}}},
+		{"CAA", `128 issue letsencrypt.org; validationmethods=dns-01`, []interface{}{map[string]interface{}{
+			"flags": 128, "tag": "issue", "value": "letsencrypt.org; validationmethods=dns-01",
+		}}},
+		{"TLSA", "3 1 1 0123456789abcdef", []interface{}{map[string]interface{}{
+			"usage": 3, "selector": 1, "matching_type": 1, "data": "0123456789abcdef",
+		}}},
+		{"SSHFP", "4 2 0123456789abcdef", []interface{}{map[string]interface{}{
+			"algorithm": 4, "fingerprint_type": 2, "fingerprint": "0123456789abcdef",
+		}}},
+		{"SRV", "5 sip.example.com", nil},
+		{"SRV", "five 5060 sip.example.com", nil},
+		{"A", "192.0.2.1", nil},
+	}
+
+	for _, tt := range tests {
+		if got := parseStructuredRecordContent(tt.rtype, tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseStructuredRecordContent(%q, %q) = %v, want %v", tt.rtype, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestStructuredRecordContentRoundTrip(t *testing.T) {
+	tests := []struct {
+		rtype   string
+		content string
+	}{
+		{"SRV", "5 5060 sip.example.com"},
+		{"CAA", `0 issue "letsencrypt.org"`},
+		{"CAA", `0 iodef "mailto:a\"b\\c@example.com"`},
+		{"CAA", "0 issue \"ca.example.net\tx\""},
+		{"TLSA", "3 1 1 0123456789abcdef"},
+		{"SSHFP", "4 2 0123456789abcdef"},
+	}
+
+	for _, tt := range tests {
+		content, ok := structuredRecordContent(tt.rtype, parseStructuredRecordContent(tt.rtype, tt.content))
+		if !ok || content != tt.content {
+			t.Errorf("structuredRecordContent(parseStructuredRecordContent(%q, %q)) = %q, %v", tt.rtype, tt.content, content, ok)
+		}
+	}
+}
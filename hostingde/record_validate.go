@@ -0,0 +1,132 @@
+package hostingde
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// recordTypes are the record types which can be managed through the API.
+var recordTypes = []string{
+	"A", "AAAA", "ALIAS", "CAA", "CERT", "CNAME", "DNSKEY", "DS", "MX", "NS",
+	"NULLMX", "OPENPGPKEY", "PTR", "SRV", "SSHFP", "TLSA", "TXT",
+}
+
+var hostnameLabelRegexp = regexp.MustCompile(`^([a-zA-Z0-9_]|[a-zA-Z0-9_][a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])$`)
+
+var caaTagRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// validateRecordContent checks the content of a record of the given type, e.g. that A records contain an IPv4 address.
+func validateRecordContent(rtype string, content string) error {
+	switch rtype {
+	case "A":
+		if ip := net.ParseIP(content); ip == nil || ip.To4() == nil || strings.Contains(content, ":") {
+			return fmt.Errorf("content of A record must be an IPv4 address, got %q", content)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(content); ip == nil || !strings.Contains(content, ":") {
+			return fmt.Errorf("content of AAAA record must be an IPv6 address, got %q", content)
+		}
+	case "CNAME", "NS", "PTR", "ALIAS", "MX":
+		if err := validateHostname(content); err != nil {
+			return fmt.Errorf("content of %s record: %v", rtype, err)
+		}
+	case "CAA":
+		return validateCAAContent(content)
+	case "SRV":
+		return validateSRVContent(content)
+	case "TLSA":
+		return validateHexFieldsContent(rtype, content, []string{"usage", "selector", "matching type"}, []int{3, 1, 2})
+	case "SSHFP":
+		return validateHexFieldsContent(rtype, content, []string{"algorithm", "fingerprint type"}, []int{255, 255})
+	}
+	return nil
+}
+
+// validateHostname checks the syntax of a hostname, which must not be an IP address.
+func validateHostname(hostname string) error {
+	if net.ParseIP(hostname) != nil {
+		return fmt.Errorf("%q is an IP address, expected a hostname", hostname)
+	}
+
+	name := strings.TrimSuffix(hostname, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("%q is not a valid hostname", hostname)
+	}
+	for i, label := range strings.Split(name, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if !hostnameLabelRegexp.MatchString(label) {
+			return fmt.Errorf("%q is not a valid hostname, invalid label %q", hostname, label)
+		}
+	}
+	return nil
+}
+
+// validateCAAContent checks content of the form `<flags> <tag> "<value>"`, see RFC 8659.
+func validateCAAContent(content string) error {
+	fields := strings.SplitN(content, " ", 3)
+	if len(fields) != 3 {
+		return fmt.Errorf("content of CAA record must be of the form <flags> <tag> \"<value>\", got %q", content)
+	}
+	if err := validateUint8Field("CAA", "flags", fields[0], 255); err != nil {
+		return err
+	}
+	if !caaTagRegexp.MatchString(fields[1]) {
+		return fmt.Errorf("tag of CAA record must be alphanumeric, got %q", fields[1])
+	}
+	value := fields[2]
+	if strings.HasPrefix(value, `"`) != strings.HasSuffix(value, `"`) || value == `"` {
+		return fmt.Errorf("value of CAA record has unbalanced quotes: %s", value)
+	}
+	return nil
+}
+
+// validateSRVContent checks content of the form <weight> <port> <target>, the priority is set separately.
+func validateSRVContent(content string) error {
+	fields := strings.Fields(content)
+	if len(fields) != 3 {
+		return fmt.Errorf("content of SRV record must be of the form <weight> <port> <target>, got %q", content)
+	}
+	for i, name := range []string{"weight", "port"} {
+		if v, err := strconv.Atoi(fields[i]); err != nil || v < 0 || v > 65535 {
+			return fmt.Errorf("%s of SRV record must be a number between 0 and 65535, got %q", name, fields[i])
+		}
+	}
+	if fields[2] != "." {
+		if err := validateHostname(fields[2]); err != nil {
+			return fmt.Errorf("target of SRV record: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateHexFieldsContent checks content consisting of numeric fields followed by hex data, as used by TLSA and SSHFP.
+func validateHexFieldsContent(rtype string, content string, names []string, max []int) error {
+	fields := strings.Fields(content)
+	if len(fields) < len(names)+1 {
+		return fmt.Errorf("content of %s record must be of the form <%s> <data>, got %q",
+			rtype, strings.Join(names, "> <"), content)
+	}
+	for i, name := range names {
+		if err := validateUint8Field(rtype, name, fields[i], max[i]); err != nil {
+			return err
+		}
+	}
+	data := strings.Join(fields[len(names):], "")
+	if _, err := hex.DecodeString(data); err != nil {
+		return fmt.Errorf("data of %s record must be hex encoded, got %q", rtype, data)
+	}
+	return nil
+}
+
+func validateUint8Field(rtype string, name string, value string, max int) error {
+	if v, err := strconv.Atoi(value); err != nil || v < 0 || v > max {
+		return fmt.Errorf("%s of %s record must be a number between 0 and %d, got %q", name, rtype, max, value)
+	}
+	return nil
+}
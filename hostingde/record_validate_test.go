@@ -0,0 +1,51 @@
+package hostingde
+
+import (
+	"testing"
+)
+
+func TestValidateRecordContent(t *testing.T) {
+	tests := []struct {
+		rtype   string
+		content string
+		valid   bool
+	}{
+		{"A", "192.0.2.1", true},
+		{"A", "2001:db8::1", false},
+		{"A", "::ffff:192.0.2.1", false},
+		{"A", "www.example.com", false},
+		{"AAAA", "2001:db8::1", true},
+		{"AAAA", "192.0.2.1", false},
+		{"CNAME", "www.example.com.", true},
+		{"CNAME", "*.example.com", true},
+		{"CNAME", "192.0.2.1", false},
+		{"CNAME", "-invalid.example.com", false},
+		{"MX", "mail.example.com", true},
+		{"CAA", `0 issue "letsencrypt.org"`, true},
+		{"CAA", `0 issue letsencrypt.org`, true},
+		{"CAA", `0 issue "letsencrypt.org`, false},
+		{"CAA", `256 issue "letsencrypt.org"`, false},
+		{"CAA", `0 iss-ue "letsencrypt.org"`, false},
+		{"CAA", `0 issue`, false},
+		{"SRV", "5 5060 sip.example.com", true},
+		{"SRV", "0 0 .", true},
+		{"SRV", "5 65536 sip.example.com", false},
+		{"SRV", "10 5 5060 sip.example.com", false},
+		{"TLSA", "3 1 1 0123456789abcdef", true},
+		{"TLSA", "4 1 1 0123456789abcdef", false},
+		{"TLSA", "3 1 1 xyz", false},
+		{"SSHFP", "4 2 0123456789ABCDEF 0123", true},
+		{"SSHFP", "4 2", false},
+		{"TXT", "v=spf1 -all", true},
+	}
+
+	for _, tt := range tests {
+		err := validateRecordContent(tt.rtype, tt.content)
+		if tt.valid && err != nil {
+			t.Errorf("validateRecordContent(%q, %q) returned error: %v", tt.rtype, tt.content, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("validateRecordContent(%q, %q) returned no error", tt.rtype, tt.content)
+		}
+	}
+}
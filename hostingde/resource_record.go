@@ -1,8 +1,12 @@
 package hostingde
 
 import (
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strings"
 )
 
 func resourceRecord() *schema.Resource {
@@ -11,40 +15,119 @@ func resourceRecord() *schema.Resource {
 		Read:   resourceRecordRead,
 		Update: resourceRecordUpdate,
 		Delete: resourceRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceRecordImport,
+		},
+		CustomizeDiff: resourceRecordCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"zone_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentRecordName,
+				Description:      "The record name, either fully-qualified or relative to the zone, e.g. \"www\" or \"@\".",
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(recordTypes, false),
 			},
 			"content": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentRecordContent,
+				Description:      "The record content, empty if it is set with a srv, caa, tlsa or sshfp block.",
+			},
+			"owner_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The account owning the record, overrides the owner_account_id of the provider.",
+			},
+			"fqdn": {
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
 			},
+			"srv":   structuredRecordSchema("SRV"),
+			"caa":   structuredRecordSchema("CAA"),
+			"tlsa":  structuredRecordSchema("TLSA"),
+			"sshfp": structuredRecordSchema("SSHFP"),
 			"ttl": {
 				Type:     schema.TypeInt,
 				Required: false,
 				Optional: true,
 				Default:  60,
 			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 		},
 	}
 }
 
-func resourceRecordCreate(d *schema.ResourceData, m interface{}) error {
-	return resourceRecordCreateOrUpdate(d, m, "")
+// recordTypeRequiresPriority reports whether records of the given type need a priority.
+func recordTypeRequiresPriority(rtype string) bool {
+	return rtype == "MX" || rtype == "SRV"
+}
+
+func resourceRecordCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	c := resourceClient(d, m)
+
+	if d.HasChange("name") {
+		if err := d.SetNewComputed("fqdn"); err != nil {
+			return err
+		}
+	}
+
+	rtype := d.Get("type").(string)
+	if _, ok := d.GetOkExists("priority"); !ok && d.NewValueKnown("priority") && recordTypeRequiresPriority(rtype) {
+		return fmt.Errorf("priority is required for %s records", rtype)
+	}
+
+	content := d.Get("content").(string)
+	contentKnown := d.NewValueKnown("content")
+	if blockType, block := structuredRecordBlock(d.GetOk); blockType != "" {
+		if blockType != rtype {
+			return fmt.Errorf("%s block can only be used for %s records", structuredRecords[blockType].block, blockType)
+		}
+		content, _ = structuredRecordContent(blockType, block)
+		contentKnown = structuredRecordKnown(blockType, d.NewValueKnown)
+	} else if contentKnown && content == "" {
+		return fmt.Errorf("one of content or a srv, caa, tlsa or sshfp block is required")
+	}
+
+	if d.NewValueKnown("type") && contentKnown {
+		if err := validateRecordContent(rtype, content); err != nil {
+			return err
+		}
+	}
+
+	if d.NewValueKnown("zone_id") && (d.Id() == "" || d.HasChange("zone_id")) {
+		// a single lookup, the zone does not need to be active to plan the record
+		resp, err := c.listZoneConfigs(ZoneConfigsFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter:      FilterOrChain{Filter: Filter{Field: "ZoneConfigId", Value: d.Get("zone_id").(string)}},
+			Limit:       1,
+			Page:        1,
+		})
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if err == nil && resp.Response.Data[0].Type == "SLAVE" {
+			return fmt.Errorf("records cannot be added to SLAVE zone %s", resp.Response.Data[0].Name)
+		}
+	}
+	return nil
 }
 
 func resourceRecordRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
+	c := resourceClient(d, m)
 	req := RecordsFindRequest{
 		BaseRequest: &BaseRequest{},
 		Filter: FilterOrChain{
@@ -64,36 +147,63 @@ func resourceRecordRead(d *schema.ResourceData, m interface{}) error {
 		Page:  1,
 	}
 	record, err := c.getRecord(req)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("[WARN] record %s not found in zone %s, removing from state", d.Id(), d.Get("zone_id").(string))
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// keep the configured name if it is an equivalent form, e.g. relative to the zone
+	zoneName, err := c.zoneName(d.Get("zone_id").(string))
 	if err != nil {
 		return err
 	}
-	_ = d.Set("name", record.Name)
+	name := d.Get("name").(string)
+	if name == "" {
+		// e.g. after import, the name is set relative to the zone
+		_ = d.Set("name", relativeRecordName(record.Name, zoneName))
+	} else if expandRecordName(name, zoneName) != normalizeRecordName(record.Name) {
+		_ = d.Set("name", record.Name)
+	}
+	_ = d.Set("fqdn", record.Name)
 	_ = d.Set("type", record.Type)
-	_ = d.Set("content", record.Content)
+	// records configured with a nested block keep content empty
+	if r, ok := structuredRecords[record.Type]; ok && len(d.Get(r.block).([]interface{})) > 0 {
+		_ = d.Set(r.block, parseStructuredRecordContent(record.Type, record.Content))
+		_ = d.Set("content", "")
+	} else {
+		_ = d.Set("content", record.Content)
+	}
 	_ = d.Set("ttl", record.TTL)
+	if recordTypeRequiresPriority(record.Type) {
+		_ = d.Set("priority", record.Priority)
+	}
 	d.SetId(record.ID)
 	return nil
 }
 
+// resourceRecordUpdate modifies the record in place, keeping its ID.
 func resourceRecordUpdate(d *schema.ResourceData, m interface{}) error {
-	return resourceRecordCreateOrUpdate(d, m, d.Id())
-}
-
-func resourceRecordDelete(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
-
-	zoneConfig, err := getZoneConfig(d.Get("zone_id").(string), c)
+	c := resourceClient(d, m)
+	record, err := expandRecord(d, c)
 	if err != nil {
 		return err
 	}
+	record.ID = d.Id()
 
-	req := ZoneUpdateRequest{
-		BaseRequest:     &BaseRequest{},
-		ZoneConfig:      *zoneConfig,
-		RecordsToDelete: []DNSRecord{{ID: d.Id()}},
+	if err := c.modifyRecords(d.Get("zone_id").(string), []DNSRecord{record}); err != nil {
+		return err
 	}
 
-	resp, err := c.updateZone(req)
+	return resourceRecordRead(d, m)
+}
+
+func resourceRecordDelete(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+
+	resp, err := c.updateZoneRecords(d.Get("zone_id").(string), nil, []DNSRecord{{ID: d.Id()}})
 	if err != nil {
 		return err
 	}
@@ -107,53 +217,95 @@ func resourceRecordDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceRecordCreateOrUpdate(d *schema.ResourceData, m interface{}, record_id string) error {
-	c := m.(*Client)
-	newRecord := DNSRecord{
-		Name:    d.Get("name").(string),
-		Type:    d.Get("type").(string),
-		Content: d.Get("content").(string),
-		TTL:     d.Get("ttl").(int),
+// resourceRecordImport accepts either <zone>/<recordId> or <zone>/<name>/<type>/<content>,
+// where <zone> is a ZoneConfig ID or a zone name, optionally prefixed with <ownerAccountId>:.
+func resourceRecordImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importOwnerAccount(d)
+	c := resourceClient(d, m)
+
+	parts := strings.SplitN(d.Id(), "/", 4)
+	if len(parts) != 2 && len(parts) != 4 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <zone>/<recordId> or <zone>/<name>/<type>/<content>", d.Id())
 	}
 
-	zoneConfig, err := getZoneConfig(d.Get("zone_id").(string), c)
-	if err != nil {
-		d.SetId("")
-		return nil
+	zoneId := parts[0]
+	if strings.Contains(zoneId, ".") {
+		zoneConfig, err := getZoneConfigByName(zoneId, c)
+		if err != nil {
+			return nil, fmt.Errorf("could not find zone %q: %v", zoneId, err)
+		}
+		zoneId = zoneConfig.ID
+	}
+
+	filters := []Filter{{Field: "ZoneConfigId", Value: zoneId}}
+	if len(parts) == 2 {
+		filters = append(filters, Filter{Field: "RecordId", Value: parts[1]})
+	} else {
+		zoneName, err := c.zoneName(zoneId)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters,
+			Filter{Field: "RecordName", Value: expandRecordName(parts[1], zoneName)},
+			Filter{Field: "RecordType", Value: parts[2]},
+			Filter{Field: "RecordContent", Value: parts[3]},
+		)
 	}
 
-	zoneUpdateReq := ZoneUpdateRequest{
-		BaseRequest:     &BaseRequest{},
-		ZoneConfig:      *zoneConfig,
-		RecordsToAdd:    []DNSRecord{newRecord},
-		RecordsToDelete: nil,
+	req := RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{
+			SubFilterConnective: "AND",
+			SubFilter:           filters,
+		},
+		Limit: 2,
+		Page:  1,
+	}
+	resp, err := c.listRecords(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not find record %q: %v", d.Id(), err)
+	}
+	if len(resp.Response.Data) > 1 {
+		return nil, fmt.Errorf("import ID %q matches more than one record, use <zone>/<recordId> instead", d.Id())
 	}
 
-	if record_id != "" {
-		zoneUpdateReq.RecordsToDelete = []DNSRecord{{ID: record_id}}
+	_ = d.Set("zone_id", zoneId)
+	d.SetId(resp.Response.Data[0].ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRecordCreate(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	newRecord, err := expandRecord(d, c)
+	if err != nil {
+		return err
 	}
 
-	resp, err := c.updateZone(zoneUpdateReq)
+	created, err := c.createZoneRecords(d.Get("zone_id").(string), []DNSRecord{newRecord})
 	if err != nil {
 		return err
 	}
 
-	for _, r := range resp.Response.Records {
-		if r.Name != newRecord.Name {
-			continue
-		}
-		if r.Type != newRecord.Type {
-			continue
-		}
-		if r.Content != newRecord.Content {
-			continue
-		}
-		if r.TTL != newRecord.TTL {
-			continue
-		}
-		d.SetId(r.ID)
-		return resourceRecordRead(d, m)
+	d.SetId(created[0].ID)
+	return resourceRecordRead(d, m)
+}
+
+// expandRecord builds the record from the configuration, resolving the name relative to the zone.
+func expandRecord(d *schema.ResourceData, c *Client) (DNSRecord, error) {
+	zoneName, err := c.zoneName(d.Get("zone_id").(string))
+	if err != nil {
+		return DNSRecord{}, err
 	}
 
-	return fmt.Errorf("response from server did not contain created record %w", resp.Response.Records)
+	record := DNSRecord{
+		Name:     expandRecordName(d.Get("name").(string), zoneName),
+		Type:     d.Get("type").(string),
+		Content:  d.Get("content").(string),
+		TTL:      d.Get("ttl").(int),
+		Priority: d.Get("priority").(int),
+	}
+	if blockType, block := structuredRecordBlock(d.GetOk); blockType == record.Type {
+		record.Content, _ = structuredRecordContent(blockType, block)
+	}
+	return record, nil
 }
@@ -0,0 +1,247 @@
+package hostingde
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+	"strings"
+)
+
+func resourceRecordSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRecordSetCreateOrUpdate,
+		Read:   resourceRecordSetRead,
+		Update: resourceRecordSetCreateOrUpdate,
+		Delete: resourceRecordSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceRecordSetImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The account owning the records, overrides the owner_account_id of the provider.",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentRecordName,
+				Description:      "The record name, either fully-qualified or relative to the zone, e.g. \"www\" or \"@\".",
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ttl": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+			},
+			"records": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The record contents, prefixed with the priority for MX and SRV records, e.g. \"10 mail.example.com\".",
+			},
+		},
+	}
+}
+
+func resourceRecordSetCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	zoneId := d.Get("zone_id").(string)
+	name := d.Get("name").(string)
+	rtype := d.Get("type").(string)
+
+	fqdn, err := recordSetFQDN(d, c)
+	if err != nil {
+		return err
+	}
+
+	actual, err := listRecordSet(c, zoneId, fqdn, rtype)
+	if err != nil {
+		return err
+	}
+
+	desired, err := expandRecordSet(d, fqdn)
+	if err != nil {
+		return err
+	}
+
+	recordsToAdd, recordsToDelete := diffRecords(desired, actual)
+	if len(recordsToAdd) > 0 || len(recordsToDelete) > 0 {
+		if _, err := c.updateZoneRecords(zoneId, recordsToAdd, recordsToDelete); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(strings.Join([]string{zoneId, name, rtype}, "/"))
+	return resourceRecordSetRead(d, m)
+}
+
+func resourceRecordSetRead(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	rtype := d.Get("type").(string)
+
+	fqdn, err := recordSetFQDN(d, c)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("[WARN] zone %s of record set %s not found, removing from state", d.Get("zone_id").(string), d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	records, err := listRecordSet(c, d.Get("zone_id").(string), fqdn, rtype)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		log.Printf("[WARN] record set %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// keep the configured form of equivalent contents, e.g. TXT values without quotes
+	if configured, err := expandRecordSet(d, fqdn); err == nil {
+		records = keepConfiguredRecordValues(configured, records)
+	}
+
+	values := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		values = append(values, recordToRecordSetValue(r))
+	}
+	_ = d.Set("fqdn", records[0].Name)
+	_ = d.Set("ttl", records[0].TTL)
+	return d.Set("records", values)
+}
+
+func resourceRecordSetDelete(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	zoneId := d.Get("zone_id").(string)
+
+	fqdn, err := recordSetFQDN(d, c)
+	if err != nil {
+		return err
+	}
+
+	records, err := listRecordSet(c, zoneId, fqdn, d.Get("type").(string))
+	if err != nil {
+		return err
+	}
+
+	_, recordsToDelete := diffRecords(nil, records)
+	if len(recordsToDelete) == 0 {
+		return nil
+	}
+	_, err = c.updateZoneRecords(zoneId, nil, recordsToDelete)
+	return err
+}
+
+// resourceRecordSetImport accepts <zone>/<name>/<type>, where <zone> is a ZoneConfig ID or a zone name,
+// optionally prefixed with <ownerAccountId>:.
+func resourceRecordSetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importOwnerAccount(d)
+	c := resourceClient(d, m)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <zone>/<name>/<type>", d.Id())
+	}
+
+	zoneId := parts[0]
+	if strings.Contains(zoneId, ".") {
+		zoneConfig, err := getZoneConfigByName(zoneId, c)
+		if err != nil {
+			return nil, fmt.Errorf("could not find zone %q: %v", zoneId, err)
+		}
+		zoneId = zoneConfig.ID
+	}
+
+	_ = d.Set("zone_id", zoneId)
+	_ = d.Set("name", parts[1])
+	_ = d.Set("type", parts[2])
+	d.SetId(strings.Join([]string{zoneId, parts[1], parts[2]}, "/"))
+	return []*schema.ResourceData{d}, nil
+}
+
+// recordSetFQDN returns the fully-qualified name of the record set, resolving a name relative to the zone.
+func recordSetFQDN(d *schema.ResourceData, c *Client) (string, error) {
+	zoneName, err := c.zoneName(d.Get("zone_id").(string))
+	if err != nil {
+		return "", err
+	}
+	return expandRecordName(d.Get("name").(string), zoneName), nil
+}
+
+// expandRecordSet returns the records configured in records with the fully-qualified name of the record set.
+func expandRecordSet(d *schema.ResourceData, fqdn string) ([]DNSRecord, error) {
+	var records []DNSRecord
+	for _, value := range d.Get("records").(*schema.Set).List() {
+		record, err := recordSetValueToRecord(d.Get("type").(string), value.(string))
+		if err != nil {
+			return nil, err
+		}
+		record.Name = fqdn
+		record.TTL = d.Get("ttl").(int)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func listRecordSet(c *Client, zoneId string, fqdn string, rtype string) ([]DNSRecord, error) {
+	req := RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{
+			SubFilterConnective: "AND",
+			SubFilter: []Filter{
+				{Field: "ZoneConfigId", Value: zoneId},
+				{Field: "RecordName", Value: fqdn},
+				{Field: "RecordType", Value: rtype},
+			},
+		},
+	}
+	return c.listAllRecords(req)
+}
+
+// recordSetValueToRecord parses a record set value, which is prefixed with the priority for MX and SRV records.
+func recordSetValueToRecord(rtype string, value string) (DNSRecord, error) {
+	record := DNSRecord{Type: rtype, Content: value}
+	if !recordTypeRequiresPriority(rtype) {
+		return record, nil
+	}
+
+	parts := strings.SplitN(value, " ", 2)
+	priority, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) != 2 {
+		return record, fmt.Errorf("%s record %q must be prefixed with its priority, e.g. \"10 %s\"", rtype, value, value)
+	}
+	record.Priority = priority
+	record.Content = strings.TrimSpace(parts[1])
+	return record, nil
+}
+
+func recordToRecordSetValue(r DNSRecord) string {
+	if recordTypeRequiresPriority(r.Type) {
+		return fmt.Sprintf("%d %s", r.Priority, r.Content)
+	}
+	return r.Content
+}
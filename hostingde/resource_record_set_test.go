@@ -0,0 +1,39 @@
+package hostingde
+
+import (
+	"testing"
+)
+
+func TestRecordSetValueToRecord(t *testing.T) {
+	tests := []struct {
+		rtype    string
+		value    string
+		content  string
+		priority int
+		valid    bool
+	}{
+		{"TXT", "v=spf1 -all", "v=spf1 -all", 0, true},
+		{"MX", "10 mail.example.com", "mail.example.com", 10, true},
+		{"MX", "0 mail.example.com", "mail.example.com", 0, true},
+		{"SRV", "0 5 5060 sip.example.com", "5 5060 sip.example.com", 0, true},
+		{"MX", "mail.example.com", "", 0, false},
+		{"MX", "10", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		record, err := recordSetValueToRecord(tt.rtype, tt.value)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("recordSetValueToRecord(%q, %q) returned no error", tt.rtype, tt.value)
+			}
+			continue
+		}
+		if err != nil || record.Content != tt.content || record.Priority != tt.priority {
+			t.Errorf("recordSetValueToRecord(%q, %q) = %v, %v", tt.rtype, tt.value, record, err)
+			continue
+		}
+		if value := recordToRecordSetValue(record); value != tt.value {
+			t.Errorf("recordToRecordSetValue(%v) = %q, want %q", record, value, tt.value)
+		}
+	}
+}
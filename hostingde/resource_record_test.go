@@ -0,0 +1,43 @@
+package hostingde
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"testing"
+)
+
+// unknownValue is the value of configuration attributes interpolated from resources not created yet.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestRecordCustomizeDiffStructuredBlock(t *testing.T) {
+	s := &testZoneServer{}
+	c, closeServer := newTestClient(s.ServeHTTP)
+	defer closeServer()
+
+	tests := []struct {
+		name  string
+		srv   map[string]interface{}
+		valid bool
+	}{
+		{"known fields", map[string]interface{}{"weight": 5, "port": 5060, "target": "sip.example.com"}, true},
+		{"unknown target", map[string]interface{}{"weight": 5, "port": 5060, "target": unknownValue}, true},
+		{"unknown port", map[string]interface{}{"weight": 5, "port": unknownValue, "target": "sip.example.com"}, true},
+		{"invalid target", map[string]interface{}{"weight": 5, "port": 5060, "target": "-invalid"}, false},
+	}
+
+	for _, tt := range tests {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone_id":  "zone1",
+			"name":     "_sip._tcp",
+			"type":     "SRV",
+			"priority": 0,
+			"srv":      []interface{}{tt.srv},
+		})
+		_, err := resourceRecord().Diff(nil, config, c)
+		if tt.valid && err != nil {
+			t.Errorf("%s: Diff returned error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: Diff returned no error", tt.name)
+		}
+	}
+}
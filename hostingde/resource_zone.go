@@ -1,7 +1,12 @@
 package hostingde
 
 import (
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strings"
 )
 
 func resourceZone() *schema.Resource {
@@ -10,36 +15,164 @@ func resourceZone() *schema.Resource {
 		Read:   resourceZoneRead,
 		Update: resourceZoneUpdate,
 		Delete: resourceZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceZoneImport,
+		},
+		CustomizeDiff: resourceZoneCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"owner_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The account owning the zone, overrides the owner_account_id of the provider.",
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"migrate_on_rename": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Rename the zone by creating the new zone with all records and deleting the old one, instead of replacing it. Records managed by hostingde_record end up duplicated.",
+			},
 			"type": {
+				Type:         schema.TypeString,
+				Required:     false,
+				Optional:     true,
+				Default:      "NATIVE",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"NATIVE", "MASTER", "SLAVE"}, false),
+			},
+			"email_address": {
 				Type:     schema.TypeString,
-				Required: false,
 				Optional: true,
+				Computed: true,
+			},
+			"master_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.SingleIP(),
+			},
+			"zone_transfer_whitelist": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_server_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"dnssec_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"off", "automatic"}, false),
+			},
+			"dnssec_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_tag": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"flags": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"digest_type": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"digest": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dnskey_record": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ds_record": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ds_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"soa_values": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"refresh": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"retry": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"expire": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"negative_ttl": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
 func resourceZoneCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
-	name := d.Get("name").(string)
-	ztype := d.Get("type").(string)
-	if ztype == "" {
-		ztype = "NATIVE"
+	c := resourceClient(d, m)
+	zoneConfig := ZoneConfig{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+	}
+	if zoneConfig.Type == "" {
+		zoneConfig.Type = "NATIVE"
 	}
+	expandZoneConfig(d, &zoneConfig)
 
 	req := ZoneCreateRequest{
 		BaseRequest:             &BaseRequest{},
 		UseDefaultNameserverSet: true,
-		ZoneConfig: ZoneConfig{
-			Name: name,
-			Type: ztype,
-		},
+		ZoneConfig:              zoneConfig,
 	}
 	resp, err := c.createZone(req)
 	if err != nil {
@@ -47,11 +180,14 @@ func resourceZoneCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(resp.Response.ZoneConfig.ID)
+	if err := waitForZoneDNSSecKeys(d, c); err != nil {
+		return err
+	}
 	return resourceZoneRead(d, m)
 }
 
 func resourceZoneRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
+	c := resourceClient(d, m)
 	req := ZoneConfigsFindRequest{
 		BaseRequest: &BaseRequest{},
 		Filter: FilterOrChain{Filter: Filter{
@@ -62,24 +198,39 @@ func resourceZoneRead(d *schema.ResourceData, m interface{}) error {
 		Page:  1,
 	}
 	resp, err := c.getZone(req)
-	if err != nil {
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("[WARN] zone %s not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 	_ = d.Set("name", resp.Name)
+	_ = d.Set("type", resp.Type)
+	_ = d.Set("account_id", resp.AccountID)
+	_ = d.Set("email_address", resp.EMailAddress)
+	_ = d.Set("master_ip", resp.MasterIP)
+	_ = d.Set("zone_transfer_whitelist", resp.ZoneTransferWhitelist)
+	_ = d.Set("dns_server_group_id", resp.DNSServerGroupID)
+	_ = d.Set("dnssec_mode", resp.DNSSecMode)
+	_ = d.Set("soa_values", flattenSOAValues(resp.SOAValues))
 	d.SetId(resp.ID)
-	return nil
+	return readZoneDNSSecKeys(d, c, resp)
 }
 
 func resourceZoneUpdate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
+	c := resourceClient(d, m)
+
+	if d.HasChange("name") {
+		return migrateZone(d, m)
+	}
 
 	zoneConfig, err := getZoneConfig(d.Id(), c)
 	if err != nil {
-		d.SetId("")
-		return nil
+		return err
 	}
-	zoneConfig.Name = d.Get("name").(string)
+	expandZoneConfig(d, zoneConfig)
 
 	req := ZoneUpdateRequest{
 		BaseRequest: &BaseRequest{},
@@ -91,11 +242,91 @@ func resourceZoneUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(resp.Response.ZoneConfig.ID)
+	if d.HasChange("dnssec_mode") {
+		if err := waitForZoneDNSSecKeys(d, c); err != nil {
+			return err
+		}
+	}
+	return resourceZoneRead(d, m)
+}
+
+// migrateZone renames a zone, which the API does not support, by creating a zone with the new name,
+// copying all records with their names rewritten to the new zone and deleting the old zone.
+// The zone ID changes, which the plan cannot show, so records managed by hostingde_record are only
+// recreated on the next apply and then exist twice, as they have been copied as well.
+func migrateZone(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	oldName, newName := d.GetChange("name")
+
+	records, err := c.listAllRecords(RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter:      FilterOrChain{Filter: Filter{Field: "ZoneConfigId", Value: d.Id()}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var newRecords []DNSRecord
+	for _, r := range records {
+		if isPlatformRecordType(r.Type) {
+			continue
+		}
+		newRecords = append(newRecords, DNSRecord{
+			Name:     renameRecordZone(r.Name, oldName.(string), newName.(string)),
+			Type:     r.Type,
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Priority: r.Priority,
+		})
+	}
+
+	zoneConfig := ZoneConfig{
+		Name: newName.(string),
+		Type: d.Get("type").(string),
+	}
+	expandZoneConfig(d, &zoneConfig)
+
+	resp, err := c.createZone(ZoneCreateRequest{
+		BaseRequest:             &BaseRequest{},
+		UseDefaultNameserverSet: true,
+		ZoneConfig:              zoneConfig,
+		Records:                 newRecords,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create zone %s to migrate %s: %v", newName, oldName, err)
+	}
+
+	// the new zone is tracked from here on, a failed delete leaves the old zone behind, not the new one
+	oldId := d.Id()
+	d.SetId(resp.Response.ZoneConfig.ID)
+
+	if _, err := c.deleteZone(ZoneDeleteRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: oldId}); err != nil {
+		return fmt.Errorf("migrated zone %s to %s, but could not delete the old zone %s: %v", oldName, newName, oldId, err)
+	}
+
+	if err := waitForZoneDNSSecKeys(d, c); err != nil {
+		return err
+	}
 	return resourceZoneRead(d, m)
 }
 
+// renameRecordZone rewrites a record name of the old zone to the new zone.
+func renameRecordZone(name string, oldZone string, newZone string) string {
+	normalized := normalizeRecordName(name)
+	oldZone = normalizeRecordName(oldZone)
+	newZone = normalizeRecordName(newZone)
+
+	if normalized == oldZone {
+		return newZone
+	}
+	if strings.HasSuffix(normalized, "."+oldZone) {
+		return strings.TrimSuffix(normalized, oldZone) + newZone
+	}
+	return name
+}
+
 func resourceZoneDelete(d *schema.ResourceData, m interface{}) error {
-	c := m.(*Client)
+	c := resourceClient(d, m)
 	req := ZoneDeleteRequest{
 		BaseRequest:  &BaseRequest{},
 		ZoneConfigId: d.Id(),
@@ -109,6 +340,132 @@ func resourceZoneDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// resourceZoneCustomizeDiff replaces renamed zones unless migrate_on_rename is set, ensures SLAVE zones have a
+// master IP and only NATIVE and MASTER zones allow zone transfers.
+func resourceZoneCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("name") && !d.Get("migrate_on_rename").(bool) {
+		if err := d.ForceNew("name"); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	ztype := d.Get("type").(string)
+	masterIP := d.Get("master_ip").(string)
+	if ztype == "SLAVE" && masterIP == "" && d.NewValueKnown("master_ip") {
+		return fmt.Errorf("master_ip is required for SLAVE zones")
+	}
+	if ztype != "SLAVE" && masterIP != "" {
+		return fmt.Errorf("master_ip can only be set for SLAVE zones")
+	}
+	if ztype == "SLAVE" && len(d.Get("zone_transfer_whitelist").([]interface{})) > 0 {
+		return fmt.Errorf("zone_transfer_whitelist can only be set for NATIVE and MASTER zones")
+	}
+	return nil
+}
+
+// expandZoneConfig applies the configurable zone settings to zoneConfig.
+func expandZoneConfig(d *schema.ResourceData, zoneConfig *ZoneConfig) {
+	zoneConfig.MasterIP = d.Get("master_ip").(string)
+
+	zoneConfig.ZoneTransferWhitelist = []string{}
+	for _, ip := range d.Get("zone_transfer_whitelist").([]interface{}) {
+		zoneConfig.ZoneTransferWhitelist = append(zoneConfig.ZoneTransferWhitelist, ip.(string))
+	}
+
+	if v, ok := d.GetOk("email_address"); ok {
+		zoneConfig.EMailAddress = v.(string)
+	}
+	if v, ok := d.GetOk("dns_server_group_id"); ok {
+		zoneConfig.DNSServerGroupID = v.(string)
+	}
+	if v, ok := d.GetOk("dnssec_mode"); ok {
+		zoneConfig.DNSSecMode = v.(string)
+	}
+	if v, ok := d.GetOk("soa_values"); ok {
+		soaValues := v.([]interface{})[0].(map[string]interface{})
+		zoneConfig.SOAValues = &SOAValues{
+			Refresh:     soaValues["refresh"].(int),
+			Retry:       soaValues["retry"].(int),
+			Expire:      soaValues["expire"].(int),
+			TTL:         soaValues["ttl"].(int),
+			NegativeTTL: soaValues["negative_ttl"].(int),
+		}
+	}
+}
+
+func flattenSOAValues(soaValues *SOAValues) []interface{} {
+	if soaValues == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"refresh":      soaValues.Refresh,
+			"retry":        soaValues.Retry,
+			"expire":       soaValues.Expire,
+			"ttl":          soaValues.TTL,
+			"negative_ttl": soaValues.NegativeTTL,
+		},
+	}
+}
+
+// waitForZoneDNSSecKeys waits until the keys of a zone with automatic DNSSEC have been generated.
+func waitForZoneDNSSecKeys(d *schema.ResourceData, c *Client) error {
+	if d.Get("dnssec_mode").(string) != "automatic" {
+		return nil
+	}
+	_, err := c.waitForDNSSecKeys(d.Get("name").(string))
+	return err
+}
+
+func readZoneDNSSecKeys(d *schema.ResourceData, c *Client, zoneConfig *ZoneConfig) error {
+	var keys []DNSSecKey
+	if zoneConfig.DNSSecMode == "automatic" {
+		options, err := c.getDNSSecOptions(DNSSecOptionsGetRequest{BaseRequest: &BaseRequest{}, ZoneName: zoneConfig.Name})
+		if err != nil {
+			return err
+		}
+		keys = options.Keys
+	}
+
+	dnsSecKeys, err := flattenDNSSecKeys(zoneConfig.Name, keys)
+	if err != nil {
+		return err
+	}
+
+	dsRecords := []string{}
+	for _, key := range dnsSecKeys {
+		key := key.(map[string]interface{})
+		if key["flags"].(int) == dnsKeyFlagsKSK {
+			dsRecords = append(dsRecords, key["ds_record"].(string))
+		}
+	}
+
+	_ = d.Set("dnssec_keys", dnsSecKeys)
+	_ = d.Set("ds_records", dsRecords)
+	return nil
+}
+
+// resourceZoneImport accepts either a ZoneConfig ID or a zone name, optionally prefixed with <ownerAccountId>:.
+func resourceZoneImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importOwnerAccount(d)
+	c := resourceClient(d, m)
+
+	if strings.Contains(d.Id(), ".") {
+		zoneConfig, err := getZoneConfigByName(d.Id(), c)
+		if err != nil {
+			return nil, fmt.Errorf("could not find zone %q: %v", d.Id(), err)
+		}
+		d.SetId(zoneConfig.ID)
+	}
+
+	_ = d.Set("migrate_on_rename", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func getZoneConfig(zoneId string, c *Client) (*ZoneConfig, error) {
 	zoneFindReq := ZoneConfigsFindRequest{
 		BaseRequest: &BaseRequest{},
@@ -122,3 +479,17 @@ func getZoneConfig(zoneId string, c *Client) (*ZoneConfig, error) {
 	zoneConfig, err := c.getZone(zoneFindReq)
 	return zoneConfig, err
 }
+
+func getZoneConfigByName(name string, c *Client) (*ZoneConfig, error) {
+	zoneFindReq := ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneName",
+			Value: strings.TrimSuffix(name, "."),
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+	zoneConfig, err := c.getZone(zoneFindReq)
+	return zoneConfig, err
+}
@@ -0,0 +1,189 @@
+package hostingde
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// platformRecordTypes are record types maintained by hosting.de for every zone.
+var platformRecordTypes = []string{"NS", "SOA"}
+
+func resourceZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceZoneRecordsCreateOrUpdate,
+		Read:   resourceZoneRecordsRead,
+		Update: resourceZoneRecordsCreateOrUpdate,
+		Delete: resourceZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceZoneRecordsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The account owning the records, overrides the owner_account_id of the provider.",
+			},
+			"ignore_platform_records": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Ignore the NS and SOA records maintained by hosting.de.",
+			},
+			"record": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  60,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceZoneRecordsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+	zoneId := d.Get("zone_id").(string)
+
+	actual, err := listZoneRecords(d, c)
+	if err != nil {
+		return err
+	}
+
+	recordsToAdd, recordsToDelete := diffRecords(expandZoneRecords(d), actual)
+	if len(recordsToAdd) > 0 || len(recordsToDelete) > 0 {
+		if _, err := c.updateZoneRecords(zoneId, recordsToAdd, recordsToDelete); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(zoneId)
+	return resourceZoneRecordsRead(d, m)
+}
+
+func resourceZoneRecordsRead(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+
+	records, err := listZoneRecords(d, c)
+	if err != nil {
+		return err
+	}
+
+	// keep the configured form of equivalent names and contents, so the set hashes do not change
+	records = keepConfiguredRecordValues(expandZoneRecords(d), records)
+
+	result := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		result = append(result, map[string]interface{}{
+			"name":     r.Name,
+			"type":     r.Type,
+			"content":  r.Content,
+			"ttl":      r.TTL,
+			"priority": r.Priority,
+		})
+	}
+	return d.Set("record", result)
+}
+
+func resourceZoneRecordsDelete(d *schema.ResourceData, m interface{}) error {
+	c := resourceClient(d, m)
+
+	records, err := listZoneRecords(d, c)
+	if err != nil {
+		return err
+	}
+
+	_, recordsToDelete := diffRecords(nil, records)
+	if len(recordsToDelete) == 0 {
+		return nil
+	}
+	_, err = c.updateZoneRecords(d.Get("zone_id").(string), nil, recordsToDelete)
+	return err
+}
+
+// resourceZoneRecordsImport accepts a ZoneConfig ID, optionally prefixed with <ownerAccountId>:.
+func resourceZoneRecordsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importOwnerAccount(d)
+	_ = d.Set("zone_id", d.Id())
+	_ = d.Set("ignore_platform_records", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandZoneRecords returns the records configured in the record blocks.
+func expandZoneRecords(d *schema.ResourceData) []DNSRecord {
+	var records []DNSRecord
+	for _, r := range d.Get("record").(*schema.Set).List() {
+		r := r.(map[string]interface{})
+		records = append(records, DNSRecord{
+			Name:     r["name"].(string),
+			Type:     r["type"].(string),
+			Content:  r["content"].(string),
+			TTL:      r["ttl"].(int),
+			Priority: r["priority"].(int),
+		})
+	}
+	return records
+}
+
+// listZoneRecords returns all records of the zone, without the platform records if these are ignored.
+func listZoneRecords(d *schema.ResourceData, c *Client) ([]DNSRecord, error) {
+	req := RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneConfigId",
+			Value: d.Get("zone_id").(string),
+		}},
+	}
+	records, err := c.listAllRecords(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.Get("ignore_platform_records").(bool) {
+		return records, nil
+	}
+
+	var filtered []DNSRecord
+	for _, r := range records {
+		if !isPlatformRecordType(r.Type) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+func isPlatformRecordType(rtype string) bool {
+	for _, t := range platformRecordTypes {
+		if rtype == t {
+			return true
+		}
+	}
+	return false
+}